@@ -0,0 +1,199 @@
+package logging
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrSinkClosed is returned when an entry is written to a closed sink.
+var ErrSinkClosed = errors.New("logging: sink is closed")
+
+// OverflowPolicy defines what AsyncSink does when its queue is full.
+type OverflowPolicy int
+
+const (
+	OverflowBlock      OverflowPolicy = iota // Wait for free space in the queue
+	OverflowDropNewest                       // Drop the new entry
+	OverflowDropOldest                       // Drop the oldest queued entry
+	OverflowDropBelow                        // Drop the new entry if its level is below DropBelow, wait otherwise
+)
+
+// AsyncOptions configures AsyncSink.
+type AsyncOptions struct {
+	QueueSize int            // Queue capacity (default 1024)
+	Overflow  OverflowPolicy // Policy for a full queue
+	DropBelow Level          // Minimal level which is never dropped with OverflowDropBelow
+	OnError   func(error)    // Called with errors of the wrapped sink (optional)
+}
+
+// AsyncSink is a Sink which puts entries to a bounded queue drained by a background goroutine,
+// so slow destinations don't block logging goroutines.
+type AsyncSink struct {
+	sink    Sink
+	opts    AsyncOptions
+	queue   chan *Entry
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	sendMu  sync.Mutex    // Keeps queued in the order of the queue
+	queued  atomic.Uint64 // Number of entries put to the queue
+	mu      sync.Mutex    // Guards removed
+	cond    *sync.Cond
+	removed uint64 // Number of entries taken from the queue, written or dropped
+
+	closeMu sync.RWMutex // Guards closed and the queue closing
+	closed  bool
+}
+
+// NewAsyncSink creates an AsyncSink and starts its background goroutine.
+//
+// Parameters:
+//   - sink - sink to write entries to
+//   - opts - queue options
+func NewAsyncSink(sink Sink, opts AsyncOptions) *AsyncSink {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1024
+	}
+
+	a := &AsyncSink{
+		sink:  sink,
+		opts:  opts,
+		queue: make(chan *Entry, opts.QueueSize),
+		done:  make(chan struct{}),
+	}
+	a.cond = sync.NewCond(&a.mu)
+
+	go a.run()
+
+	return a
+}
+
+// WriteEntry puts a copy of the entry to the queue according to the overflow policy.
+//
+// Parameters:
+//   - e - entry to write
+func (a *AsyncSink) WriteEntry(e *Entry) error {
+	a.closeMu.RLock()
+	defer a.closeMu.RUnlock()
+
+	if a.closed {
+		return ErrSinkClosed
+	}
+
+	entry := *e
+	a.sendMu.Lock()
+	if a.enqueue(&entry) {
+		a.queued.Add(1)
+	} else {
+		a.dropped.Add(1)
+	}
+	a.sendMu.Unlock()
+
+	return nil
+}
+
+// Dropped returns the number of entries dropped because the queue was full.
+func (a *AsyncSink) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Flush waits until entries queued before the call are written and flushes the wrapped sink.
+// Entries queued by other goroutines during the call are not waited for.
+func (a *AsyncSink) Flush() error {
+	target := a.queued.Load()
+
+	a.mu.Lock()
+	for a.removed < target {
+		a.cond.Wait()
+	}
+	a.mu.Unlock()
+
+	if f, ok := a.sink.(Flusher); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// Close writes queued entries, stops the background goroutine and closes the wrapped sink
+// if it implements io.Closer.
+func (a *AsyncSink) Close() error {
+	a.closeMu.Lock()
+	if a.closed {
+		a.closeMu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.closeMu.Unlock()
+
+	<-a.done
+
+	if c, ok := a.sink.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// enqueue puts an entry to the queue. It returns false if the entry was dropped.
+//
+// Parameters:
+//   - e - entry to put
+func (a *AsyncSink) enqueue(e *Entry) bool {
+	select {
+	case a.queue <- e:
+		return true
+	default:
+	}
+
+	switch a.opts.Overflow {
+	case OverflowDropNewest:
+		return false
+	case OverflowDropOldest:
+		for {
+			select {
+			case a.queue <- e:
+				return true
+			default:
+			}
+
+			select {
+			case <-a.queue:
+				a.addRemoved()
+				a.dropped.Add(1)
+			default:
+			}
+		}
+	case OverflowDropBelow:
+		if e.Level < a.opts.DropBelow {
+			return false
+		}
+	}
+
+	a.queue <- e
+
+	return true
+}
+
+// run writes queued entries to the wrapped sink.
+func (a *AsyncSink) run() {
+	defer close(a.done)
+
+	for e := range a.queue {
+		if err := a.sink.WriteEntry(e); err != nil && a.opts.OnError != nil {
+			a.opts.OnError(err)
+		}
+		a.addRemoved()
+	}
+}
+
+// addRemoved counts an entry taken from the queue and wakes up Flush.
+func (a *AsyncSink) addRemoved() {
+	a.mu.Lock()
+	a.removed++
+	a.cond.Broadcast()
+	a.mu.Unlock()
+}
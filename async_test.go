@@ -0,0 +1,185 @@
+package logging
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// gatedSink records messages and blocks until the gate is opened
+type gatedSink struct {
+	started chan struct{}
+	gate    chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	msgs    []string
+	closed  bool
+}
+
+func newGatedSink() *gatedSink {
+	return &gatedSink{started: make(chan struct{}), gate: make(chan struct{})}
+}
+
+func (s *gatedSink) WriteEntry(e *Entry) error {
+	s.once.Do(func() { close(s.started) })
+	<-s.gate
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs = append(s.msgs, e.Message)
+
+	return nil
+}
+
+func (s *gatedSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestAsyncSink_Overflow(t *testing.T) {
+	testCases := []struct {
+		policy  OverflowPolicy
+		want    []string
+		dropped uint64
+	}{
+		{OverflowDropNewest, []string{"e1", "e2", "e3"}, 2},
+		{OverflowDropOldest, []string{"e1", "e4", "e5"}, 2},
+		{OverflowDropBelow, []string{"e1", "e2", "e3", "e5"}, 1},
+		{OverflowBlock, []string{"e1", "e2", "e3", "e4", "e5"}, 0},
+	}
+
+	for _, tc := range testCases {
+		sink := newGatedSink()
+		async := NewAsyncSink(sink, AsyncOptions{QueueSize: 2, Overflow: tc.policy, DropBelow: LevelError})
+
+		require.NoError(t, async.WriteEntry(&Entry{Level: LevelInfo, Message: "e1"}))
+		<-sink.started // e1 is taken by the background goroutine
+
+		require.NoError(t, async.WriteEntry(&Entry{Level: LevelInfo, Message: "e2"}))
+		require.NoError(t, async.WriteEntry(&Entry{Level: LevelInfo, Message: "e3"}))
+
+		// e4 and e5 may wait for free space in the queue
+		if tc.policy != OverflowBlock {
+			async.WriteEntry(&Entry{Level: LevelInfo, Message: "e4"})
+		}
+		written := make(chan struct{})
+		go func() {
+			defer close(written)
+			if tc.policy == OverflowBlock {
+				async.WriteEntry(&Entry{Level: LevelInfo, Message: "e4"})
+			}
+			async.WriteEntry(&Entry{Level: LevelError, Message: "e5"})
+		}()
+		if tc.policy != OverflowBlock && tc.policy != OverflowDropBelow {
+			<-written
+		}
+
+		close(sink.gate)
+		<-written
+		require.NoError(t, async.Flush())
+
+		require.Equal(t, tc.want, sink.msgs, "policy %d", tc.policy)
+		require.Equal(t, tc.dropped, async.Dropped(), "policy %d", tc.policy)
+
+		require.NoError(t, async.Close())
+		require.True(t, sink.closed)
+		require.ErrorIs(t, async.WriteEntry(&Entry{Message: "late"}), ErrSinkClosed)
+	}
+}
+
+func TestAsyncSink_Logging(t *testing.T) {
+	var buf bytes.Buffer
+	async := NewAsyncSink(&WriterSink{Writer: &buf}, AsyncOptions{})
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Sink: async}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				logger.Info("async")
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.NoError(t, logger.Flush())
+	require.Equal(t, 100, bytes.Count(buf.Bytes(), []byte("INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tasync\n")))
+	require.NoError(t, async.Close())
+}
+
+func TestAsyncSink_FlushWhileLogging(t *testing.T) {
+	var buf bytes.Buffer
+	async := NewAsyncSink(&WriterSink{Writer: &buf}, AsyncOptions{QueueSize: 4})
+	logger := &Logging{UUID: "id", Sink: async}
+
+	logger.Info("before flush")
+
+	// Other goroutines keep logging, Flush waits only for entries queued before the call
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					logger.Info("noise")
+				}
+			}
+		}()
+	}
+
+	for range 10 {
+		require.NoError(t, logger.Flush())
+	}
+	close(stop)
+	wg.Wait()
+
+	require.NoError(t, async.Close())
+	require.Contains(t, buf.String(), "before flush")
+}
+
+func TestLogging_FlushTimeout(t *testing.T) {
+	sink := newGatedSink()
+	async := NewAsyncSink(sink, AsyncOptions{})
+	logger := &Logging{Sink: async}
+
+	// A stuck sink doesn't block exit forever
+	logger.Info("stuck")
+	<-sink.started
+	require.False(t, logger.flushTimeout(20*time.Millisecond))
+
+	close(sink.gate)
+	require.True(t, logger.flushTimeout(time.Second))
+	require.Equal(t, []string{"stuck"}, sink.msgs)
+	require.NoError(t, async.Close())
+}
+
+func TestLogging_PanicFlushTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { exitFlushTimeout = timeout }(exitFlushTimeout)
+	exitFlushTimeout = 20 * time.Millisecond
+
+	sink := newGatedSink()
+	async := NewAsyncSink(sink, AsyncOptions{})
+	logger := &Logging{Sink: async}
+
+	logger.Info("stuck")
+	<-sink.started
+
+	// A stuck sink doesn't prevent the panic
+	require.PanicsWithValue(t, "boom", func() { logger.Panic("boom") })
+	require.PanicsWithValue(t, "boom 1", func() { logger.Panicf("boom %d", 1) })
+	require.PanicsWithValue(t, "boom", func() { logger.Panicw("boom", "n", 1) })
+
+	close(sink.gate)
+	require.NoError(t, async.Close())
+	require.Equal(t, []string{"stuck", "boom", "boom 1", "boom"}, sink.msgs)
+}
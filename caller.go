@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Caller is the source location of a log call.
+type Caller struct {
+	File     string // Full path of the source file
+	Line     int    // Line number
+	Function string // Fully qualified function name (empty if ShowFunction is not set)
+}
+
+// Defined reports whether the caller was captured.
+func (c Caller) Defined() bool {
+	return c.File != ""
+}
+
+// String returns the caller as "dir/file.go:42", the file path is trimmed to the package directory.
+func (c Caller) String() string {
+	return string(c.appendLocation(nil))
+}
+
+// ShortFunction returns the function name without the package path, e.g. "logging.(*Logging).Info".
+func (c Caller) ShortFunction() string {
+	return c.Function[strings.LastIndexByte(c.Function, '/')+1:]
+}
+
+// appendLocation appends the trimmed file path and line to dst.
+//
+// Parameters:
+//   - dst - buffer to append to
+func (c Caller) appendLocation(dst []byte) []byte {
+	file := c.File
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		if j := strings.LastIndexByte(file[:i], '/'); j >= 0 {
+			file = file[j+1:]
+		}
+	}
+
+	dst = append(dst, file...)
+	dst = append(dst, ':')
+
+	return strconv.AppendInt(dst, int64(c.Line), 10)
+}
+
+// callerFromPC resolves a program counter returned by runtime.Callers to a caller.
+//
+// Parameters:
+//   - pc - program counter
+//   - withFunction - resolve the function name
+func callerFromPC(pc uintptr, withFunction bool) Caller {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+
+	caller := Caller{File: frame.File, Line: frame.Line}
+	if withFunction {
+		caller.Function = frame.Function
+	}
+
+	return caller
+}
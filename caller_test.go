@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// callerSink records callers of written entries
+type callerSink struct {
+	callers []Caller
+}
+
+func (s *callerSink) WriteEntry(e *Entry) error {
+	s.callers = append(s.callers, e.Caller)
+	return nil
+}
+
+// line returns the line number of its caller
+func line() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+// logHelper is a user wrapper which reports its own caller
+func logHelper(logger *Logging, msg string) {
+	logger.AddCallerSkip(1).Info(msg)
+}
+
+func TestLogging_Caller(t *testing.T) {
+	sink := &callerSink{}
+	logger := &Logging{Sink: sink, ShowCaller: true}
+	custom := &CustomLogger{}
+	custom.SetLogger(logger.Named("custom"))
+
+	var want []int
+	want = append(want, line()+1)
+	logger.Info("info")
+	want = append(want, line()+1)
+	logger.Errorw("errorw", "key", 1)
+	want = append(want, line()+1)
+	logger.Log(LevelWarn, "log")
+	want = append(want, line()+1)
+	logger.Print(4, "print")
+	want = append(want, line()+1)
+	logger.With("key", 1).Debugf("%d", 1)
+	want = append(want, line()+1)
+	logger.Starting("test")
+	want = append(want, line()+1)
+	logHelper(logger, "helper")
+	want = append(want, line()+1)
+	custom.Warn("custom")
+	want = append(want, line()+1)
+	custom.Infow("custom", "key", 1)
+	want = append(want, line()+1)
+	slog.New(NewSlogHandler(logger)).Info("slog")
+
+	require.Len(t, sink.callers, len(want))
+	for i, c := range sink.callers {
+		require.Equal(t, "caller_test.go", filepath.Base(c.File), "entry %d", i)
+		require.Equal(t, want[i], c.Line, "entry %d", i)
+		require.Empty(t, c.Function, "entry %d", i)
+	}
+
+	// The function is resolved only with ShowFunction
+	sink.callers = nil
+	logger.SetShowFunction(true)
+	logger.Warn("function")
+	require.Equal(t, "github.com/ra-company/logging.TestLogging_Caller", sink.callers[0].Function)
+	require.Equal(t, "logging.TestLogging_Caller", sink.callers[0].ShortFunction())
+
+	sink.callers = nil
+	logger.SetShowCaller(false)
+	logger.Warn("no caller")
+	require.False(t, sink.callers[0].Defined())
+}
+
+func TestCustomLogger_Caller(t *testing.T) {
+	sink := &callerSink{}
+	logger := &Logging{Sink: sink, ShowCaller: true}
+	custom := &CustomLogger{}
+	custom.SetLogger(NewSlogHandlerLogger(NewSlogHandler(logger)))
+
+	want := line() + 1
+	custom.Error("slog logger")
+	want2 := line() + 1
+	custom.Errorw("slog logger", "key", 1)
+
+	require.Len(t, sink.callers, 2)
+	require.Equal(t, want, sink.callers[0].Line)
+	require.Equal(t, want2, sink.callers[1].Line)
+
+	// Without a logger CustomLogger writes to the global Logs instance
+	var buf bytes.Buffer
+	Logs.SetOutput(&buf)
+	Logs.SetShowCaller(true)
+	defer func() {
+		Logs.SetOutput(nil)
+		Logs.SetShowCaller(false)
+	}()
+
+	want = line() + 1
+	(&CustomLogger{}).Error("default")
+	require.Contains(t, buf.String(), "/caller_test.go:"+strconv.Itoa(want)+"\tdefault\n")
+}
+
+func TestCaller_String(t *testing.T) {
+	c := Caller{File: "/src/github.com/ra-company/logging/caller.go", Line: 42, Function: "github.com/ra-company/logging.(*Logging).Info"}
+	require.Equal(t, "logging/caller.go:42", c.String())
+	require.Equal(t, "logging.(*Logging).Info", c.ShortFunction())
+
+	c.File = "main.go"
+	c.Function = "main.main"
+	require.Equal(t, "main.go:42", c.String())
+	require.Equal(t, "main.main", c.ShortFunction())
+}
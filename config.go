@@ -0,0 +1,393 @@
+package logging
+
+import (
+	"context"
+	"io"
+)
+
+// config is a consistent snapshot of the logger configuration
+type config struct {
+	uuid         string
+	level        Level
+	consoleApp   bool
+	showTime     bool
+	dontStop     bool
+	output       io.Writer
+	encoder      Encoder
+	sink         Sink
+	title        string
+	showCaller   bool
+	showFunction bool
+	showStack    bool
+	stackLevel   *Level
+	stackDepth   int
+	trace        TraceExtractor
+	extractors   []ContextExtractor
+}
+
+// config returns a consistent snapshot of the root logger configuration
+// with the level and UUID of a derived logger applied
+func (logger *Logging) config() config {
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	cfg := config{
+		uuid:         root.UUID,
+		level:        root.effectiveLevel(),
+		consoleApp:   root.ConsoleApp,
+		showTime:     root.ShowTime,
+		dontStop:     root.DontStop,
+		output:       root.Output,
+		encoder:      root.Encoder,
+		sink:         root.Sink,
+		title:        root.title,
+		showCaller:   root.ShowCaller,
+		showFunction: root.ShowFunction,
+		showStack:    root.ShowStack,
+		stackLevel:   root.StackLevel,
+		stackDepth:   root.StackDepth,
+		trace:        root.TraceExtractor,
+		extractors:   root.extractors,
+	}
+	if logger.levelOverride != nil {
+		cfg.level = *logger.levelOverride
+	}
+	if logger.uuidOverride != "" {
+		cfg.uuid = logger.uuidOverride
+	}
+
+	return cfg
+}
+
+// contextFields returns fields extracted from the context of a log call.
+//
+// Parameters:
+//   - ctx - context
+func (cfg *config) contextFields(ctx context.Context) []Field {
+	var fields []Field
+
+	var tc TraceContext
+	var ok bool
+	if cfg.trace != nil {
+		tc, ok = cfg.trace.ExtractTrace(ctx)
+	} else {
+		tc, ok = TraceFromContext(ctx)
+	}
+	if ok {
+		fields = tc.fields()
+	}
+
+	for _, e := range cfg.extractors {
+		fields = append(fields, e.ExtractFields(ctx)...)
+	}
+
+	return fields
+}
+
+// stackEnabled reports whether entries at the level get stack traces.
+//
+// Parameters:
+//   - level - log level
+func (cfg *config) stackEnabled(level Level) bool {
+	if !cfg.showStack {
+		return false
+	}
+
+	minLevel := LevelError
+	if cfg.stackLevel != nil {
+		minLevel = *cfg.stackLevel
+	}
+
+	return level >= minLevel
+}
+
+// callersDepth returns the number of stack frames to capture for an entry at the level
+// (0 if neither caller nor stack trace is reported).
+//
+// Parameters:
+//   - level - log level
+func (cfg *config) callersDepth(level Level) int {
+	if level < cfg.level {
+		return 0
+	}
+
+	if cfg.stackEnabled(level) {
+		if cfg.stackDepth <= 0 {
+			return defaultStackDepth
+		}
+		return cfg.stackDepth
+	}
+
+	if cfg.showCaller {
+		return 1
+	}
+
+	return 0
+}
+
+// SetUUID sets the global process UUID used when context has no UUID.
+//
+// Parameters:
+//   - uuid - new value
+func (logger *Logging) SetUUID(uuid string) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.UUID = uuid
+}
+
+// GetUUID returns the process UUID used when context has no UUID.
+func (logger *Logging) GetUUID() string {
+	if logger.uuidOverride != "" {
+		return logger.uuidOverride
+	}
+
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	return root.UUID
+}
+
+// SetLevel sets the minimal log level.
+//
+// Parameters:
+//   - level - new value
+func (logger *Logging) SetLevel(level Level) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.level = level
+	root.LogLevel = level.Int()
+}
+
+// Level returns the minimal log level.
+func (logger *Logging) Level() Level {
+	if logger.levelOverride != nil {
+		return *logger.levelOverride
+	}
+
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	return root.effectiveLevel()
+}
+
+// SetLogLevel sets the minimal log level using the old integer levels
+// (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info).
+//
+// Parameters:
+//   - level - new value
+func (logger *Logging) SetLogLevel(level int) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.level = LevelFromInt(level)
+	root.LogLevel = level
+}
+
+// GetLogLevel returns the minimal log level as an old integer level
+// (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info).
+func (logger *Logging) GetLogLevel() int {
+	return logger.Level().Int()
+}
+
+// effectiveLevel returns the minimal log level.
+// If LogLevel was assigned directly, it takes precedence over the level set by SetLevel.
+// The caller must hold cfgMu.
+func (logger *Logging) effectiveLevel() Level {
+	if logger.level.Int() != logger.LogLevel {
+		return LevelFromInt(logger.LogLevel)
+	}
+
+	return logger.level
+}
+
+// SetConsoleApp sets the console application flag.
+//
+// Parameters:
+//   - consoleApp - new value
+func (logger *Logging) SetConsoleApp(consoleApp bool) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.ConsoleApp = consoleApp
+}
+
+// GetConsoleApp returns the console application flag.
+func (logger *Logging) GetConsoleApp() bool {
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	return root.ConsoleApp
+}
+
+// SetShowTime sets the flag to show time in logs.
+//
+// Parameters:
+//   - showTime - new value
+func (logger *Logging) SetShowTime(showTime bool) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.ShowTime = showTime
+}
+
+// GetShowTime returns the flag to show time in logs.
+func (logger *Logging) GetShowTime() bool {
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	return root.ShowTime
+}
+
+// SetDontStop sets the flag to not stop service on fatal error.
+//
+// Parameters:
+//   - dontStop - new value
+func (logger *Logging) SetDontStop(dontStop bool) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.DontStop = dontStop
+}
+
+// GetDontStop returns the flag to not stop service on fatal error.
+func (logger *Logging) GetDontStop() bool {
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	return root.DontStop
+}
+
+// SetOutput sets the output destination (nil means os.Stdout).
+//
+// Parameters:
+//   - output - new value
+func (logger *Logging) SetOutput(output io.Writer) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.Output = output
+}
+
+// SetEncoder sets the output format (nil means TextEncoder).
+//
+// Parameters:
+//   - encoder - new value
+func (logger *Logging) SetEncoder(encoder Encoder) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.Encoder = encoder
+}
+
+// SetSink sets the entry destination used instead of Output and Encoder (nil means Output is used).
+//
+// Parameters:
+//   - sink - new value
+func (logger *Logging) SetSink(sink Sink) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.Sink = sink
+}
+
+// SetShowCaller sets the flag to show caller file:line in logs.
+//
+// Parameters:
+//   - showCaller - new value
+func (logger *Logging) SetShowCaller(showCaller bool) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.ShowCaller = showCaller
+}
+
+// GetShowCaller returns the flag to show caller file:line in logs.
+func (logger *Logging) GetShowCaller() bool {
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	return root.ShowCaller
+}
+
+// SetShowFunction sets the flag to show caller function name in logs.
+// It takes effect only if ShowCaller is set.
+//
+// Parameters:
+//   - showFunction - new value
+func (logger *Logging) SetShowFunction(showFunction bool) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.ShowFunction = showFunction
+}
+
+// GetShowFunction returns the flag to show caller function name in logs.
+func (logger *Logging) GetShowFunction() bool {
+	root := logger.root()
+	root.cfgMu.RLock()
+	defer root.cfgMu.RUnlock()
+
+	return root.ShowFunction
+}
+
+// SetStack sets the flag to attach stack traces, the minimal level and the maximal depth of stack traces.
+//
+// Parameters:
+//   - showStack - attach stack traces to entries
+//   - level - minimal level of entries with stack traces
+//   - depth - maximal number of stack frames (0 means 32)
+func (logger *Logging) SetStack(showStack bool, level Level, depth int) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.ShowStack = showStack
+	root.StackLevel = &level
+	root.StackDepth = depth
+}
+
+// SetTraceExtractor sets the extractor of trace_id, span_id and trace_flags fields (nil means TraceFromContext).
+//
+// Parameters:
+//   - extractor - new value
+func (logger *Logging) SetTraceExtractor(extractor TraceExtractor) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	root.TraceExtractor = extractor
+}
+
+// AddContextExtractor registers extractors of fields which are added to entries logged with a context,
+// e.g. a request, tenant or user ID. Fields are added in the order of registration.
+//
+// Parameters:
+//   - extractors - extractors to add
+func (logger *Logging) AddContextExtractor(extractors ...ContextExtractor) {
+	root := logger.root()
+	root.cfgMu.Lock()
+	defer root.cfgMu.Unlock()
+
+	// copy on write, configuration snapshots share the slice
+	root.extractors = append(root.extractors[:len(root.extractors):len(root.extractors)], extractors...)
+}
@@ -0,0 +1,150 @@
+package logging
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// uuidKey is the context key of the process UUID.
+// Unlike CtxKeyUUID it can't collide with keys of other packages.
+type uuidKey struct{}
+
+// ContextWithUUID returns a copy of ctx which carries the process UUID.
+// A new random UUID is used if id is empty.
+//
+// Parameters:
+//   - ctx - parent context
+//   - id - process UUID
+func ContextWithUUID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	return context.WithValue(ctx, uuidKey{}, id)
+}
+
+// UUIDFromContext returns the process UUID stored by ContextWithUUID.
+// The value stored by CtxKeyUUID is used if there is no such UUID.
+//
+// Parameters:
+//   - ctx - context
+//
+// Returns:
+//   - string: process UUID
+//   - bool: whether ctx carries a UUID
+func UUIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+
+	if id, ok := ctx.Value(uuidKey{}).(string); ok && id != "" {
+		return id, true
+	}
+
+	id := valueString(ctx.Value(CtxKeyUUID))
+
+	return id, id != ""
+}
+
+// NewContext is ContextWithUUID.
+//
+// Parameters:
+//   - ctx - parent context
+//   - id - process UUID
+func NewContext(ctx context.Context, id string) context.Context {
+	return ContextWithUUID(ctx, id)
+}
+
+// FromContext is UUIDFromContext.
+//
+// Parameters:
+//   - ctx - context
+//
+// Returns:
+//   - string: process UUID
+//   - bool: whether ctx carries a UUID
+func FromContext(ctx context.Context) (string, bool) {
+	return UUIDFromContext(ctx)
+}
+
+// EnsureUUID returns ctx if it carries a process UUID,
+// otherwise it returns a copy of ctx with a new random UUID.
+//
+// Parameters:
+//   - ctx - context
+//
+// Returns:
+//   - context.Context: context with a UUID
+//   - string: process UUID
+func EnsureUUID(ctx context.Context) (context.Context, string) {
+	if id, ok := UUIDFromContext(ctx); ok {
+		return ctx, id
+	}
+
+	id := uuid.New().String()
+
+	return context.WithValue(ctx, uuidKey{}, id), id
+}
+
+// ChildContext returns a copy of ctx which carries an ID of a sub-task in format "parent/child",
+// so entries of the sub-task can be told apart and still be found by the parent UUID.
+// The parent is the UUID carried by ctx or the UUID of Logs, a random 8-digit hex ID is used if child is empty.
+//
+// Parameters:
+//   - ctx - parent context
+//   - child - sub-task ID, e.g. "worker-3"
+//
+// Returns:
+//   - context.Context: context with the child ID
+//   - string: child ID
+func ChildContext(ctx context.Context, child string) (context.Context, string) {
+	parent, ok := UUIDFromContext(ctx)
+	if !ok {
+		parent = Logs.GetUUID()
+	}
+
+	if child == "" {
+		child, _, _ = strings.Cut(uuid.New().String(), "-")
+	}
+
+	id := parent + "/" + child
+
+	return context.WithValue(ctx, uuidKey{}, id), id
+}
+
+// loggerKey is the context key of the logger
+type loggerKey struct{}
+
+// ContextWithLogger returns a copy of ctx which carries the logger,
+// so it can be retrieved by LoggerFromContext deep in the call stack with its fields, level and UUID.
+//
+// Parameters:
+//   - ctx - parent context
+//   - logger - logger, e.g. created by With, WithLevel or WithUUID
+func ContextWithLogger(ctx context.Context, logger *Logging) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger stored by ContextWithLogger or the global Logs instance if there is no logger.
+//
+// Parameters:
+//   - ctx - context
+func LoggerFromContext(ctx context.Context) *Logging {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*Logging); ok && logger != nil {
+			return logger
+		}
+	}
+
+	return &Logs
+}
+
+// FromCtx is LoggerFromContext.
+//
+// Parameters:
+//   - ctx - context
+func FromCtx(ctx context.Context) *Logging {
+	return LoggerFromContext(ctx)
+}
@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextWithUUID(t *testing.T) {
+	_, ok := UUIDFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := ContextWithUUID(context.Background(), "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	id, ok := UUIDFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f", id)
+
+	// A new UUID is generated for an empty ID
+	id, ok = UUIDFromContext(ContextWithUUID(context.Background(), ""))
+	require.True(t, ok)
+	require.NoError(t, uuid.Validate(id))
+
+	// The value stored by CtxKeyUUID is still honoured, the new key takes precedence
+	old := context.WithValue(context.Background(), CtxKeyUUID, "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049")
+	id, ok = UUIDFromContext(old)
+	require.True(t, ok)
+	require.Equal(t, "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", id)
+
+	id, _ = UUIDFromContext(ContextWithUUID(old, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f"))
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f", id)
+
+	// Values of other packages with the same name don't collide
+	ctx = context.WithValue(context.Background(), "process-uuid", "foreign")
+	_, ok = UUIDFromContext(ctx)
+	require.False(t, ok)
+
+	// NewContext and FromContext are the same helpers
+	id, ok = FromContext(NewContext(context.Background(), "job-1"))
+	require.True(t, ok)
+	require.Equal(t, "job-1", id)
+}
+
+func TestEnsureUUID(t *testing.T) {
+	ctx, id := EnsureUUID(context.Background())
+	require.NoError(t, uuid.Validate(id))
+
+	same, sameID := EnsureUUID(ctx)
+	require.Equal(t, ctx, same)
+	require.Equal(t, id, sameID)
+}
+
+func TestChildContext(t *testing.T) {
+	ctx := ContextWithUUID(context.Background(), "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	child, id := ChildContext(ctx, "worker-3")
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f/worker-3", id)
+
+	_, id = ChildContext(child, "")
+	require.True(t, strings.HasPrefix(id, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f/worker-3/"))
+	require.Len(t, id, len("4577c272-e9b8-4a19-a9d0-4ec0bde6063f/worker-3/")+8)
+
+	// The parent is the global UUID if the context has no UUID
+	_, id = ChildContext(context.Background(), "job")
+	require.Equal(t, Logs.GetUUID()+"/job", id)
+}
+
+func TestLogging_ContextWithUUID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Output: &buf}
+
+	ctx := ContextWithUUID(context.Background(), "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	child, _ := ChildContext(ctx, "worker-3")
+	logger.Info(ctx, "started")
+	logger.Info(child, "processing")
+
+	want := "INF\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tstarted\n" +
+		"INF\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f/worker-3]\tprocessing\n"
+	require.Equal(t, want, buf.String())
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	require.Same(t, &Logs, LoggerFromContext(context.Background()))
+	require.Same(t, &Logs, LoggerFromContext(nil))
+	require.Same(t, &Logs, FromCtx(context.Background()))
+
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Output: &buf}
+	logger.SetLevel(LevelWarn)
+
+	requestLogger := logger.With("request_id", 7).WithLevel(LevelDebug).WithUUID("4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	ctx := ContextWithLogger(context.Background(), requestLogger)
+	require.Same(t, requestLogger, FromCtx(ctx))
+
+	handle := func(ctx context.Context) {
+		LoggerFromContext(ctx).Debug("debug enabled for the request")
+		LoggerFromContext(ctx).Named("db").Info(ContextWithUUID(ctx, "c0ffee"), "query")
+	}
+	handle(ctx)
+	logger.Info("filtered by the root level")
+
+	want := "DBG\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tdebug enabled for the request\trequest_id=7\n" +
+		"INF\t[c0ffee]\tdb: query\trequest_id=7\n"
+	require.Equal(t, want, buf.String())
+
+	require.Equal(t, LevelDebug, requestLogger.Level())
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f", requestLogger.GetUUID())
+	require.Equal(t, LevelWarn, logger.Level())
+	require.Equal(t, "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", logger.GetUUID())
+}
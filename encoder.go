@@ -0,0 +1,327 @@
+package logging
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Entry is a single log record passed to an Encoder.
+type Entry struct {
+	Time    time.Time // Entry time (zero if time is not shown)
+	Level   Level     // Log level
+	UUID    string    // Process UUID
+	Title   string    // Service title set by Starting
+	Logger  string    // Logger name set by Named
+	Message string    // Log message
+	Fields  []Field   // Structured fields
+	Caller  Caller    // Source location of the log call (zero if caller is not shown)
+	Stack   string    // Stack trace, two lines per frame (empty if stack is not attached)
+	Error   error     // First error passed as a message argument (nil for methods with fields)
+}
+
+// Encoder converts log entries to their output representation.
+type Encoder interface {
+	// Encode appends the encoded entry, including the trailing newline, to dst
+	// and returns the extended buffer.
+	Encode(dst []byte, e *Entry) []byte
+}
+
+// TextEncoder encodes entries in format "TIME\tLEVEL\t[UUID]\tCALLER\tmessage".
+// Lines of the stack trace follow the entry indented by a tab.
+// It is the default encoder.
+type TextEncoder struct{}
+
+// Encode appends the tab-separated representation of the entry to dst.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - e - entry to encode
+func (TextEncoder) Encode(dst []byte, e *Entry) []byte {
+	if !e.Time.IsZero() {
+		dst = append(dst, timeToStr(e.Time)...)
+		dst = append(dst, '\t')
+	}
+
+	dst = append(dst, e.Level.Label()...)
+	dst = append(dst, "\t["...)
+	dst = append(dst, e.UUID...)
+	dst = append(dst, "]\t"...)
+	if e.Caller.Defined() {
+		dst = e.Caller.appendLocation(dst)
+		if e.Caller.Function != "" {
+			dst = append(dst, ' ')
+			dst = append(dst, e.Caller.ShortFunction()...)
+		}
+		dst = append(dst, '\t')
+	}
+	if e.Logger != "" {
+		dst = append(dst, e.Logger...)
+		dst = append(dst, ": "...)
+	}
+	dst = append(dst, e.Message...)
+	if len(e.Fields) > 0 {
+		dst = appendTextFields(append(dst, '\t'), e.Fields)
+	}
+	if e.Error != nil {
+		// the error message is already a part of the message
+		if details := errorFields("error", e.Error, false)[1:]; len(details) > 0 {
+			dst = appendTextFields(append(dst, '\t'), details)
+		}
+	}
+	for line := range strings.Lines(e.Stack) {
+		dst = append(dst, "\n\t"...)
+		dst = append(dst, strings.TrimSuffix(line, "\n")...)
+	}
+
+	return append(dst, '\n')
+}
+
+// JSONKeys defines the key names used by JSONEncoder.
+// An empty key uses the default name, a key set to "-" omits the field.
+type JSONKeys struct {
+	Time     string // Timestamp key (default "time")
+	Level    string // Level key (default "level")
+	UUID     string // Process UUID key (default "uuid")
+	Message  string // Message key (default "msg")
+	Title    string // Service title key (default "service")
+	Logger   string // Logger name key (default "logger")
+	Caller   string // Caller file:line key (default "caller")
+	Function string // Caller function key (default "func")
+	Stack    string // Stack trace key (default "stack")
+	Error    string // Error key (default "error")
+}
+
+// DefaultJSONKeys are the key names used by JSONEncoder when no other names are set.
+var DefaultJSONKeys = JSONKeys{
+	Time:     "time",
+	Level:    "level",
+	UUID:     "uuid",
+	Message:  "msg",
+	Title:    "service",
+	Logger:   "logger",
+	Caller:   "caller",
+	Function: "func",
+	Stack:    "stack",
+	Error:    "error",
+}
+
+// JSONFieldPrefix is added by JSONEncoder to the keys of fields which clash with the keys it writes.
+const JSONFieldPrefix = "fields."
+
+// JSONEncoder encodes entries as one JSON object per line.
+// Fields with the key of another field of the entry are written with JSONFieldPrefix.
+type JSONEncoder struct {
+	Keys       JSONKeys // Key names (empty keys use DefaultJSONKeys)
+	TimeFormat string   // Timestamp layout (default "2006-01-02T15:04:05.000Z07:00")
+}
+
+// Encode appends the JSON representation of the entry to dst.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - e - entry to encode
+func (enc JSONEncoder) Encode(dst []byte, e *Entry) []byte {
+	// keys of the entry fields, user fields must not repeat them
+	var used [10]string
+	n := 0
+	resolve := func(key, def string) string {
+		if key == "" {
+			return def
+		}
+		return key
+	}
+	if e.Stack != "" && enc.Keys.Stack != "-" {
+		// the stack is written after the user fields
+		used[n] = resolve(enc.Keys.Stack, DefaultJSONKeys.Stack)
+		n++
+	}
+
+	first := true
+	field := func(key, def string) bool {
+		if key == "-" {
+			return false
+		}
+		key = resolve(key, def)
+		if !slices.Contains(used[:n], key) {
+			used[n] = key
+			n++
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = appendJSONString(dst, key)
+		dst = append(dst, ':')
+		return true
+	}
+
+	dst = append(dst, '{')
+
+	if !e.Time.IsZero() && field(enc.Keys.Time, DefaultJSONKeys.Time) {
+		layout := enc.TimeFormat
+		if layout == "" {
+			layout = "2006-01-02T15:04:05.000Z07:00"
+		}
+		dst = appendJSONString(dst, e.Time.Format(layout))
+	}
+	if field(enc.Keys.Level, DefaultJSONKeys.Level) {
+		dst = appendJSONString(dst, e.Level.String())
+	}
+	if field(enc.Keys.UUID, DefaultJSONKeys.UUID) {
+		dst = appendJSONString(dst, e.UUID)
+	}
+	if e.Title != "" && field(enc.Keys.Title, DefaultJSONKeys.Title) {
+		dst = appendJSONString(dst, e.Title)
+	}
+	if e.Logger != "" && field(enc.Keys.Logger, DefaultJSONKeys.Logger) {
+		dst = appendJSONString(dst, e.Logger)
+	}
+	if e.Caller.Defined() && field(enc.Keys.Caller, DefaultJSONKeys.Caller) {
+		dst = appendJSONString(dst, e.Caller.String())
+	}
+	if e.Caller.Function != "" && field(enc.Keys.Function, DefaultJSONKeys.Function) {
+		dst = appendJSONString(dst, e.Caller.Function)
+	}
+	if field(enc.Keys.Message, DefaultJSONKeys.Message) {
+		dst = appendJSONString(dst, e.Message)
+	}
+	if e.Error != nil && field(enc.Keys.Error, DefaultJSONKeys.Error) {
+		dst = appendJSONError(dst, e.Error)
+	}
+	for _, f := range e.Fields {
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		if slices.Contains(used[:n], f.Key) {
+			// like journald FIELD_, the prefix keeps the keys of the entry unique
+			dst = appendJSONString(dst, JSONFieldPrefix+f.Key)
+		} else {
+			dst = appendJSONString(dst, f.Key)
+		}
+		dst = append(dst, ':')
+		dst = appendJSONValue(dst, f.Value)
+	}
+	if e.Stack != "" && field(enc.Keys.Stack, DefaultJSONKeys.Stack) {
+		dst = appendJSONString(dst, e.Stack)
+	}
+
+	return append(dst, '}', '\n')
+}
+
+// LogfmtEncoder encodes entries as logfmt lines:
+//
+//	ts=2025-06-17T18:17:42.016+03:00 level=info uuid=f4d14d28-ae09-4aed-958a-c6dcb6da2a89 service=Sample msg="Service started"
+type LogfmtEncoder struct {
+	TimeFormat string // Timestamp layout (default "2006-01-02T15:04:05.000Z07:00")
+}
+
+// Encode appends the logfmt representation of the entry to dst.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - e - entry to encode
+func (enc LogfmtEncoder) Encode(dst []byte, e *Entry) []byte {
+	if !e.Time.IsZero() {
+		layout := enc.TimeFormat
+		if layout == "" {
+			layout = "2006-01-02T15:04:05.000Z07:00"
+		}
+		dst = appendLogfmtValue(append(dst, "ts="...), e.Time.Format(layout))
+		dst = append(dst, ' ')
+	}
+
+	dst = appendLogfmtValue(append(dst, "level="...), e.Level.String())
+	dst = appendLogfmtValue(append(dst, " uuid="...), e.UUID)
+	if e.Title != "" {
+		dst = appendLogfmtValue(append(dst, " service="...), e.Title)
+	}
+	if e.Logger != "" {
+		dst = appendLogfmtValue(append(dst, " logger="...), e.Logger)
+	}
+	if e.Caller.Defined() {
+		dst = appendLogfmtValue(append(dst, " caller="...), e.Caller.String())
+		if e.Caller.Function != "" {
+			dst = appendLogfmtValue(append(dst, " func="...), e.Caller.Function)
+		}
+	}
+	dst = appendLogfmtValue(append(dst, " msg="...), e.Message)
+	if e.Error != nil {
+		dst = appendTextFields(append(dst, ' '), errorFields("error", e.Error, false))
+	}
+	if len(e.Fields) > 0 {
+		dst = appendTextFields(append(dst, ' '), e.Fields)
+	}
+	if e.Stack != "" {
+		dst = appendLogfmtValue(append(dst, " stack="...), e.Stack)
+	}
+
+	return append(dst, '\n')
+}
+
+// appendLogfmtValue appends a logfmt value to dst.
+// Values that are empty or contain spaces, quotes, '=' or control characters are quoted.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - s - value to append
+func appendLogfmtValue(dst []byte, s string) []byte {
+	needsQuote := s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == '\\' || r == 0x7f || r == utf8.RuneError || !strconv.IsPrint(r)
+	}) >= 0
+
+	if needsQuote {
+		return strconv.AppendQuote(dst, s)
+	}
+
+	return append(dst, s...)
+}
+
+// appendJSONString appends a quoted and escaped JSON string to dst.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - s - string to append
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	dst = append(dst, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				dst = append(dst, '\\', c)
+			case c == '\n':
+				dst = append(dst, '\\', 'n')
+			case c == '\r':
+				dst = append(dst, '\\', 'r')
+			case c == '\t':
+				dst = append(dst, '\\', 't')
+			case c < 0x20:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			default:
+				dst = append(dst, c)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			dst = append(dst, `\u202`...)
+			dst = append(dst, hex[r&0xf])
+		default:
+			dst = append(dst, s[i:i+size]...)
+		}
+		i += size
+	}
+
+	return append(dst, '"')
+}
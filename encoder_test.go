@@ -0,0 +1,164 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextEncoder(t *testing.T) {
+	entry := &Entry{
+		Time:    time.Date(2025, 6, 17, 18, 17, 42, 16000000, time.UTC),
+		Level:   LevelError,
+		UUID:    "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Message: "Hello World",
+	}
+
+	got := string(TextEncoder{}.Encode(nil, entry))
+	require.Equal(t, "2025/06/17 18:17:42.016\tERR\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tHello World\n", got)
+
+	entry.Time = time.Time{}
+	got = string(TextEncoder{}.Encode(nil, entry))
+	require.Equal(t, "ERR\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tHello World\n", got)
+}
+
+func TestJSONEncoder(t *testing.T) {
+	entry := &Entry{
+		Time:    time.Date(2025, 6, 17, 18, 17, 42, 16000000, time.UTC),
+		Level:   LevelInfo,
+		UUID:    "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Title:   "test",
+		Message: "Hello \"World\"\n\t\\ <tag> \x01 \u2028 привет",
+	}
+
+	got := JSONEncoder{}.Encode(nil, entry)
+	require.Equal(t, `{"time":"2025-06-17T18:17:42.016Z","level":"info","uuid":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","service":"test","msg":"Hello \"World\"\n\t\\ <tag> \u0001 \u2028 привет"}`+"\n", string(got))
+
+	var decoded map[string]string
+	require.NoError(t, json.Unmarshal(got, &decoded))
+	require.Equal(t, entry.Message, decoded["msg"])
+
+	enc := JSONEncoder{
+		Keys: JSONKeys{
+			Time:    "@timestamp",
+			Level:   "log.level",
+			UUID:    "trace.id",
+			Message: "message",
+			Title:   "-",
+		},
+		TimeFormat: time.RFC3339,
+	}
+
+	got = enc.Encode(nil, entry)
+	require.Equal(t, `{"@timestamp":"2025-06-17T18:17:42Z","log.level":"info","trace.id":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","message":"Hello \"World\"\n\t\\ <tag> \u0001 \u2028 привет"}`+"\n", string(got))
+
+	entry.Message = "invalid \xff utf-8"
+	require.NoError(t, json.Unmarshal(JSONEncoder{}.Encode(nil, entry), &decoded))
+	require.Equal(t, "invalid \ufffd utf-8", decoded["msg"])
+}
+
+func TestJSONEncoder_ReservedKeys(t *testing.T) {
+	entry := &Entry{
+		Level:   LevelInfo,
+		UUID:    "id",
+		Message: "dup",
+		Fields:  []Field{String("msg", "x"), String("level", "y"), String("error", "z"), String("stack", "s"), Int("n", 1)},
+	}
+
+	// Fields clashing with written keys get the prefix, others are kept as is
+	got := JSONEncoder{}.Encode(nil, entry)
+	require.Equal(t, `{"level":"info","uuid":"id","msg":"dup","fields.msg":"x","fields.level":"y","error":"z","stack":"s","n":1}`+"\n", string(got))
+
+	entry.Stack = "main.main()"
+	got = JSONEncoder{}.Encode(nil, entry)
+	require.Equal(t, `{"level":"info","uuid":"id","msg":"dup","fields.msg":"x","fields.level":"y","error":"z","fields.stack":"s","n":1,"stack":"main.main()"}`+"\n", string(got))
+
+	// Configured key names are reserved instead of the defaults
+	enc := JSONEncoder{Keys: JSONKeys{Message: "message", Level: "-", Stack: "-"}}
+	entry.Fields = append(entry.Fields, String("message", "m"))
+	got = enc.Encode(nil, entry)
+	require.Equal(t, `{"uuid":"id","message":"dup","msg":"x","level":"y","error":"z","stack":"s","n":1,"fields.message":"m"}`+"\n", string(got))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(got, &decoded))
+	require.Len(t, decoded, 8)
+}
+
+func TestLogging_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{
+		UUID:    "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Output:  &buf,
+		Encoder: JSONEncoder{},
+	}
+	logger.Starting("test")
+
+	ctx := context.WithValue(context.Background(), CtxKeyUUID, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	logger.Warnf(ctx, "Hello %s", "Universe")
+	logger.Infow("dup", "msg", "x", "level", "y")
+
+	want := `{"level":"info","uuid":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","service":"test","msg":"test service is starting..."}` + "\n" +
+		`{"level":"warn","uuid":"4577c272-e9b8-4a19-a9d0-4ec0bde6063f","service":"test","msg":"Hello Universe"}` + "\n" +
+		`{"level":"info","uuid":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","service":"test","msg":"dup","fields.msg":"x","fields.level":"y"}` + "\n"
+	require.Equal(t, want, buf.String())
+}
+
+func TestLogfmtEncoder(t *testing.T) {
+	entry := &Entry{
+		Time:    time.Date(2025, 6, 17, 18, 17, 42, 16000000, time.UTC),
+		Level:   LevelWarn,
+		UUID:    "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Title:   "test",
+		Message: "Hello",
+	}
+
+	got := string(LogfmtEncoder{}.Encode(nil, entry))
+	require.Equal(t, "ts=2025-06-17T18:17:42.016Z level=warn uuid=b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049 service=test msg=Hello\n", got)
+
+	testCases := []struct {
+		value string
+		want  string
+	}{
+		{"", `""`},
+		{"plain", `plain`},
+		{"привет", `привет`},
+		{"Hello World", `"Hello World"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{"a=b", `"a=b"`},
+		{`back\slash`, `"back\\slash"`},
+		{"multi\nline", `"multi\nline"`},
+		{"tab\there", `"tab\there"`},
+	}
+
+	for _, tc := range testCases {
+		entry := &Entry{Level: LevelInfo, UUID: "id", Message: tc.value}
+		got := string(LogfmtEncoder{}.Encode(nil, entry))
+		require.Equal(t, "level=info uuid=id msg="+tc.want+"\n", got, "value %q", tc.value)
+	}
+}
+
+func TestEncoders_Caller(t *testing.T) {
+	entry := &Entry{
+		Level:   LevelError,
+		UUID:    "id",
+		Message: "failed",
+		Caller: Caller{
+			File:     "/src/billing/invoice.go",
+			Line:     42,
+			Function: "example.com/billing.(*Service).Charge",
+		},
+	}
+
+	require.Equal(t, "ERR\t[id]\tbilling/invoice.go:42 billing.(*Service).Charge\tfailed\n", string(TextEncoder{}.Encode(nil, entry)))
+	require.Equal(t, `{"level":"error","uuid":"id","caller":"billing/invoice.go:42","func":"example.com/billing.(*Service).Charge","msg":"failed"}`+"\n", string(JSONEncoder{}.Encode(nil, entry)))
+	require.Equal(t, `level=error uuid=id caller=billing/invoice.go:42 func=example.com/billing.(*Service).Charge msg=failed`+"\n", string(LogfmtEncoder{}.Encode(nil, entry)))
+
+	entry.Caller.Function = ""
+	require.Equal(t, "ERR\t[id]\tbilling/invoice.go:42\tfailed\n", string(TextEncoder{}.Encode(nil, entry)))
+	require.Equal(t, `{"level":"error","uuid":"id","msg":"failed"}`+"\n", string(JSONEncoder{Keys: JSONKeys{Caller: "-"}}.Encode(nil, entry)))
+	require.Equal(t, "level=error uuid=id caller=billing/invoice.go:42 msg=failed\n", string(LogfmtEncoder{}.Encode(nil, entry)))
+}
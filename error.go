@@ -0,0 +1,216 @@
+package logging
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// LogFielder is an optional interface for errors which carry attributes for structured logs.
+// Attributes of all errors in the error tree are logged, attributes of outer errors take precedence.
+type LogFielder interface {
+	// LogFields returns the error attributes.
+	LogFields() map[string]any
+}
+
+// ErrorCause describes an error wrapped by a logged error.
+type ErrorCause struct {
+	Type    string // Error type, e.g. "*fs.PathError"
+	Message string // Error message
+}
+
+// ErrorCauses returns the errors wrapped by err, walking the tree built by
+// fmt.Errorf("%w") and errors.Join depth first. err itself is not included.
+//
+// Parameters:
+//   - err - error to unwrap
+func ErrorCauses(err error) []ErrorCause {
+	var causes []ErrorCause
+	for _, child := range unwrapErrors(err) {
+		walkErrors(child, func(e error) {
+			causes = append(causes, ErrorCause{Type: errorType(e), Message: errorMessage(e)})
+		})
+	}
+
+	return causes
+}
+
+// walkErrors calls fn for err and every error in its tree depth first.
+//
+// Parameters:
+//   - err - root of the error tree
+//   - fn - function to call
+func walkErrors(err error, fn func(error)) {
+	if err == nil {
+		return
+	}
+
+	fn(err)
+	for _, child := range unwrapErrors(err) {
+		walkErrors(child, fn)
+	}
+}
+
+// unwrapErrors returns the errors directly wrapped by err.
+//
+// Parameters:
+//   - err - error to unwrap
+func unwrapErrors(err error) []error {
+	if nilError(err) {
+		return nil
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if child := e.Unwrap(); child != nil {
+			return []error{child}
+		}
+	case interface{ Unwrap() []error }:
+		return e.Unwrap()
+	}
+
+	return nil
+}
+
+// nilError reports whether err is nil or a nil pointer (or other nil value) stored in the error interface.
+// Methods of such errors usually panic.
+//
+// Parameters:
+//   - err - error
+func nilError(err error) bool {
+	return isNil(err)
+}
+
+// errorMessage returns the message of an error like fmt does:
+// "<nil>" for nil pointers and the panic description if the Error method panics.
+//
+// Parameters:
+//   - err - error
+func errorMessage(err error) string {
+	return fmt.Sprint(err)
+}
+
+// errorType returns the type name of an error, e.g. "*errors.errorString".
+//
+// Parameters:
+//   - err - error
+func errorType(err error) string {
+	return fmt.Sprintf("%T", err)
+}
+
+// errorAttrs returns attributes of all errors in the tree implementing LogFielder, sorted by key.
+//
+// Parameters:
+//   - err - root of the error tree
+func errorAttrs(err error) []Field {
+	var attrs map[string]any
+	walkErrors(err, func(e error) {
+		lf, ok := e.(LogFielder)
+		if !ok || nilError(e) {
+			return
+		}
+		for k, v := range lf.LogFields() {
+			if attrs == nil {
+				attrs = map[string]any{}
+			}
+			if _, ok := attrs[k]; !ok {
+				attrs[k] = v
+			}
+		}
+	})
+
+	fields := make([]Field, 0, len(attrs))
+	for _, k := range slices.Sorted(maps.Keys(attrs)) {
+		fields = append(fields, Field{Key: k, Value: attrs[k]})
+	}
+
+	return fields
+}
+
+// errorFields flattens an error to fields for text formats:
+// key=message, key.<attribute>=value for LogFielder attributes
+// and key.causes="type: message; ..." for wrapped errors.
+//
+// Parameters:
+//   - key - field name
+//   - err - error to flatten
+//   - withType - add the key.type field
+func errorFields(key string, err error, withType bool) []Field {
+	fields := []Field{{Key: key, Value: errorMessage(err)}}
+	if withType {
+		fields = append(fields, Field{Key: key + ".type", Value: errorType(err)})
+	}
+
+	for _, attr := range errorAttrs(err) {
+		fields = append(fields, Field{Key: key + "." + attr.Key, Value: attr.Value})
+	}
+
+	if causes := ErrorCauses(err); len(causes) > 0 {
+		list := make([]string, len(causes))
+		for i, c := range causes {
+			list[i] = c.Type + ": " + c.Message
+		}
+		fields = append(fields, Field{Key: key + ".causes", Value: strings.Join(list, "; ")})
+	}
+
+	return fields
+}
+
+// appendJSONError appends an error as a JSON object to dst:
+//
+//	{"msg":"...","type":"...","fields":{...},"causes":[{"msg":"...","type":"..."}]}
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - err - error to append
+func appendJSONError(dst []byte, err error) []byte {
+	dst = append(dst, `{"msg":`...)
+	dst = appendJSONString(dst, errorMessage(err))
+	dst = append(dst, `,"type":`...)
+	dst = appendJSONString(dst, errorType(err))
+
+	if attrs := errorAttrs(err); len(attrs) > 0 {
+		dst = append(dst, `,"fields":{`...)
+		for i, attr := range attrs {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, attr.Key)
+			dst = append(dst, ':')
+			dst = appendJSONValue(dst, attr.Value)
+		}
+		dst = append(dst, '}')
+	}
+
+	if causes := ErrorCauses(err); len(causes) > 0 {
+		dst = append(dst, `,"causes":[`...)
+		for i, c := range causes {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, `{"msg":`...)
+			dst = appendJSONString(dst, c.Message)
+			dst = append(dst, `,"type":`...)
+			dst = appendJSONString(dst, c.Type)
+			dst = append(dst, '}')
+		}
+		dst = append(dst, ']')
+	}
+
+	return append(dst, '}')
+}
+
+// firstError returns the first argument which is a non-nil error or nil.
+//
+// Parameters:
+//   - args - logged arguments
+func firstError(args []any) error {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok && !nilError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// statusError is an error with attributes for structured logs
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string { return fmt.Sprintf("status %d: %v", e.status, e.err) }
+
+func (e *statusError) Unwrap() error { return e.err }
+
+func (e *statusError) LogFields() map[string]any {
+	return map[string]any{"status": e.status, "retry": false}
+}
+
+// newTestError builds the error tree:
+//
+//	*fmt.wrapError
+//	  *errors.joinError
+//	    *statusError
+//	      *fs.PathError
+//	        *errors.errorString
+//	    *errors.errorString
+func newTestError() error {
+	pathErr := &fs.PathError{Op: "open", Path: "config.yml", Err: errors.New("denied")}
+	joined := errors.Join(&statusError{status: 503, err: pathErr}, errors.New("fallback failed"))
+
+	return fmt.Errorf("load config: %w", joined)
+}
+
+func TestErrorCauses(t *testing.T) {
+	require.Nil(t, ErrorCauses(errors.New("plain")))
+	require.Equal(t, []ErrorCause{
+		{Type: "*errors.joinError", Message: "status 503: open config.yml: denied\nfallback failed"},
+		{Type: "*logging.statusError", Message: "status 503: open config.yml: denied"},
+		{Type: "*fs.PathError", Message: "open config.yml: denied"},
+		{Type: "*errors.errorString", Message: "denied"},
+		{Type: "*errors.errorString", Message: "fallback failed"},
+	}, ErrorCauses(newTestError()))
+}
+
+func TestErrorAttrs(t *testing.T) {
+	outer := &statusError{status: 500, err: &statusError{status: 404, err: errors.New("missing")}}
+	require.Equal(t, []Field{Bool("retry", false), Int("status", 500)}, errorAttrs(outer))
+	require.Empty(t, errorAttrs(errors.New("plain")))
+}
+
+func TestLogging_ErrorRendering(t *testing.T) {
+	err := &statusError{status: 503, err: errors.New("unavailable")}
+
+	testCases := []struct {
+		encoder Encoder
+		want    string
+	}{
+		{
+			TextEncoder{},
+			"ERR\t[id]\tstatus 503: unavailable\terror.retry=false error.status=503 error.causes=\"*errors.errorString: unavailable\"\n" +
+				"ERR\t[id]\tquery failed\terror=\"status 503: unavailable\" error.retry=false error.status=503 error.causes=\"*errors.errorString: unavailable\"\n" +
+				"ERR\t[id]\tboom\n",
+		},
+		{
+			JSONEncoder{},
+			`{"level":"error","uuid":"id","msg":"status 503: unavailable","error":{"msg":"status 503: unavailable","type":"*logging.statusError","fields":{"retry":false,"status":503},"causes":[{"msg":"unavailable","type":"*errors.errorString"}]}}` + "\n" +
+				`{"level":"error","uuid":"id","msg":"query failed","error":{"msg":"status 503: unavailable","type":"*logging.statusError","fields":{"retry":false,"status":503},"causes":[{"msg":"unavailable","type":"*errors.errorString"}]}}` + "\n" +
+				`{"level":"error","uuid":"id","msg":"boom","error":{"msg":"boom","type":"*errors.errorString"}}` + "\n",
+		},
+		{
+			LogfmtEncoder{},
+			`level=error uuid=id msg="status 503: unavailable" error="status 503: unavailable" error.retry=false error.status=503 error.causes="*errors.errorString: unavailable"` + "\n" +
+				`level=error uuid=id msg="query failed" error="status 503: unavailable" error.retry=false error.status=503 error.causes="*errors.errorString: unavailable"` + "\n" +
+				`level=error uuid=id msg=boom error=boom` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		logger := &Logging{UUID: "id", Output: &buf, Encoder: tc.encoder}
+
+		logger.Error(err)
+		logger.Errorw("query failed", Err(err))
+		logger.Errorf("%v", errors.New("boom"))
+		require.Equal(t, tc.want, buf.String())
+	}
+}
+
+func TestJournaldSink_Error(t *testing.T) {
+	j := &JournaldSink{Identifier: "test"}
+	entry := &Entry{
+		Level:   LevelError,
+		Message: "load failed",
+		Fields:  []Field{Err(newTestError())},
+	}
+
+	fields := parseJournal(t, j.format(entry))
+	require.Equal(t, "load config: status 503: open config.yml: denied\nfallback failed", fields["ERROR"])
+	require.Equal(t, "*fmt.wrapError", fields["ERROR_TYPE"])
+	require.Equal(t, "503", fields["ERROR_STATUS"])
+	require.Contains(t, fields["ERROR_CAUSES"], "*fs.PathError: open config.yml: denied")
+}
+
+func ExampleLogging_Error_causes() {
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"}
+
+	err := fmt.Errorf("load config: %w", &fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrPermission})
+	logger.Error(err)
+
+	// Output:
+	// ERR	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	load config: open config.yml: permission denied	error.causes="*fs.PathError: open config.yml: permission denied; *errors.errorString: permission denied"
+}
+
+func TestLogging_NilError(t *testing.T) {
+	var nilErr *statusError
+
+	for _, enc := range []Encoder{TextEncoder{}, JSONEncoder{}, LogfmtEncoder{}} {
+		var buf bytes.Buffer
+		logger := &Logging{UUID: "id", Output: &buf, Encoder: enc}
+		logger.SetStack(true, LevelError, 0)
+
+		// Nil pointers are printed as <nil> like fmt does
+		require.NotPanics(t, func() {
+			logger.Error("failed:", nilErr)
+			logger.Errorw("query failed", "err", nilErr)
+			logger.Errorw("wrapped", Err(fmt.Errorf("load: %w", nilErr)))
+		}, "%T", enc)
+		require.Contains(t, buf.String(), "<nil>", "%T", enc)
+	}
+
+	require.Equal(t, []ErrorCause{{Type: "*logging.statusError", Message: "<nil>"}}, ErrorCauses(fmt.Errorf("load: %w", nilErr)))
+	require.Empty(t, errorAttrs(nilErr))
+	require.Nil(t, firstError([]any{"failed:", nilErr}))
+	require.Empty(t, stackFromArgs([]any{(*frameError)(nil)}, 32))
+}
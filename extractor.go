@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ContextExtractor extracts fields from the context passed to logging methods,
+// e.g. a request, tenant or user ID. Extractors are registered by Logging.AddContextExtractor.
+type ContextExtractor interface {
+	// ExtractFields returns fields to add to the entry (nil if ctx has no values).
+	ExtractFields(ctx context.Context) []Field
+}
+
+// ContextExtractorFunc is an adapter to use a function as a ContextExtractor.
+type ContextExtractorFunc func(ctx context.Context) []Field
+
+// ExtractFields calls f(ctx).
+//
+// Parameters:
+//   - ctx - context
+func (f ContextExtractorFunc) ExtractFields(ctx context.Context) []Field {
+	return f(ctx)
+}
+
+// ContextValue returns an extractor which adds the context value stored by key as a field.
+// Strings, byte slices and fmt.Stringer values like uuid.UUID are converted to strings,
+// other values are added as is. Nothing is added if the context has no value.
+//
+// Parameters:
+//   - name - field name
+//   - key - context key
+func ContextValue(name string, key any) ContextExtractor {
+	return ContextExtractorFunc(func(ctx context.Context) []Field {
+		v := ctx.Value(key)
+		if isNil(v) {
+			return nil
+		}
+
+		switch v.(type) {
+		case string, []byte, fmt.Stringer:
+			return []Field{String(name, valueString(v))}
+		default:
+			return []Field{Any(name, v)}
+		}
+	})
+}
+
+// valueString converts a context value to a string.
+// Values of unknown types are formatted by fmt, nil and nil pointers are converted to an empty string.
+//
+// Parameters:
+//   - v - context value
+func valueString(v any) string {
+	if isNil(v) {
+		return ""
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		// fmt calls String and recovers from its panics
+		return fmt.Sprint(v)
+	}
+}
+
+// isNil reports whether v is nil or a nil pointer, map, slice, function or channel stored in an interface.
+//
+// Parameters:
+//   - v - value
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantKey is the context key of the test tenant ID
+type tenantKey struct{}
+
+func TestLogging_ContextUUID(t *testing.T) {
+	id := uuid.MustParse("4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	testCases := []struct {
+		value any
+		want  string
+	}{
+		{"4577c272-e9b8-4a19-a9d0-4ec0bde6063f", "4577c272-e9b8-4a19-a9d0-4ec0bde6063f"},
+		{id, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f"},
+		{[]byte("request-1"), "request-1"},
+		{42, "42"},
+		{"", "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"},
+		{nil, "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"},
+		{(*uuid.UUID)(nil), "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"},
+		{&id, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f"},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Output: &buf}
+
+		ctx := context.WithValue(context.Background(), CtxKeyUUID, tc.value)
+		require.NotPanics(t, func() { logger.Info(ctx, "hello") })
+		require.Equal(t, "INF\t["+tc.want+"]\thello\n", buf.String(), "value %#v", tc.value)
+	}
+
+	// Nil pointers are treated as missing values
+	ctx := context.WithValue(context.Background(), tenantKey{}, (*uuid.UUID)(nil))
+	require.NotPanics(t, func() {
+		require.Nil(t, ContextValue("tenant", tenantKey{}).ExtractFields(ctx))
+	})
+}
+
+func TestLogging_AddContextExtractor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "id", Output: &buf}
+	logger.AddContextExtractor(
+		ContextValue("tenant_id", tenantKey{}),
+		ContextExtractorFunc(func(ctx context.Context) []Field {
+			if user, ok := ctx.Value(CtxKey("user")).(int); ok {
+				return []Field{Int("user_id", user)}
+			}
+			return nil
+		}),
+	)
+	logger.AddContextExtractor(ContextValue("request_id", CtxKey("request")))
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, uuid.MustParse("4577c272-e9b8-4a19-a9d0-4ec0bde6063f"))
+	ctx = context.WithValue(ctx, CtxKey("user"), 7)
+	ctx = context.WithValue(ctx, CtxKey("request"), struct{ N int }{3})
+
+	child := logger.With("component", "billing")
+	child.Infow(ctx, "charged", "amount", 10)
+	child.Info(context.Background(), "empty context")
+	child.Info("no context")
+
+	want := "INF\t[id]\tcharged\tcomponent=billing tenant_id=4577c272-e9b8-4a19-a9d0-4ec0bde6063f user_id=7 request_id={3} amount=10\n" +
+		"INF\t[id]\tempty context\tcomponent=billing\n" +
+		"INF\t[id]\tno context\tcomponent=billing\n"
+	require.Equal(t, want, buf.String())
+}
@@ -0,0 +1,273 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field is a structured key/value pair attached to a log entry.
+type Field struct {
+	Key   string // Field name
+	Value any    // Field value
+}
+
+// badKey is used as a key for values which have no key.
+const badKey = "!BADKEY"
+
+// Any creates a field with a value of any type.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func Any(key string, value any) Field {
+	return Field{Key: key, Value: value}
+}
+
+// String creates a string field.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func String(key, value string) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int creates an integer field.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func Int(key string, value int) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Int64 creates a 64-bit integer field.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Float64 creates a floating-point field.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Bool creates a boolean field.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func Bool(key string, value bool) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Duration creates a time.Duration field.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Time creates a time.Time field.
+//
+// Parameters:
+//   - key - field name
+//   - value - field value
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err creates an error field with the "error" key.
+//
+// Parameters:
+//   - err - error value
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// fieldsFromArgs converts alternating key/value arguments to fields.
+// Field values are used as is, a value without a string key gets the "!BADKEY" key.
+//
+// Parameters:
+//   - args - Field values or alternating keys and values
+func fieldsFromArgs(args []any) []Field {
+	if len(args) == 0 {
+		return nil
+	}
+
+	fields := make([]Field, 0, (len(args)+1)/2)
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case Field:
+			fields = append(fields, arg)
+		case string:
+			if i+1 < len(args) {
+				fields = append(fields, Field{Key: arg, Value: args[i+1]})
+				i++
+			} else {
+				fields = append(fields, Field{Key: badKey, Value: arg})
+			}
+		default:
+			fields = append(fields, Field{Key: badKey, Value: arg})
+		}
+	}
+
+	return fields
+}
+
+// fieldString converts a field value to its text representation.
+//
+// Parameters:
+//   - value - field value
+func fieldString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "<nil>"
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02T15:04:05.000Z07:00")
+	case error:
+		return errorMessage(v)
+	default:
+		// fmt calls String and recovers from panics of nil receivers
+		return fmt.Sprint(v)
+	}
+}
+
+// appendTextFields appends fields as space-separated logfmt pairs.
+// Errors are expanded to several pairs, see errorFields.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - fields - fields to append
+func appendTextFields(dst []byte, fields []Field) []byte {
+	for i, f := range fields {
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		if err, ok := f.Value.(error); ok {
+			dst = appendTextFields(dst, errorFields(f.Key, err, false))
+			continue
+		}
+		dst = append(dst, logfmtKey(f.Key)...)
+		dst = append(dst, '=')
+		dst = appendLogfmtValue(dst, fieldString(f.Value))
+	}
+
+	return dst
+}
+
+// logfmtKey replaces characters which are not allowed in logfmt keys with underscores.
+//
+// Parameters:
+//   - key - field name
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// appendJSONValue appends a JSON representation of a field value to dst.
+// Errors are written as objects, see appendJSONError.
+// Values which can't be marshaled are written as strings.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - value - field value
+func appendJSONValue(dst []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(dst, "null"...)
+	case string:
+		return appendJSONString(dst, v)
+	case bool:
+		return strconv.AppendBool(dst, v)
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(dst, v, 10)
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(dst, v, 10)
+	case float32:
+		return appendJSONFloat(dst, float64(v), 32)
+	case float64:
+		return appendJSONFloat(dst, v, 64)
+	case time.Time:
+		return appendJSONString(dst, v.Format("2006-01-02T15:04:05.000Z07:00"))
+	case time.Duration:
+		return appendJSONString(dst, v.String())
+	case []byte:
+		return appendJSONString(dst, string(v))
+	case json.Marshaler:
+		if isNil(v) {
+			// like encoding/json, nil pointers are not marshaled
+			return append(dst, "null"...)
+		}
+		if b, err := v.MarshalJSON(); err == nil && json.Valid(b) {
+			return append(dst, b...)
+		}
+		return appendJSONString(dst, fieldString(v))
+	case error:
+		return appendJSONError(dst, v)
+	case fmt.Stringer:
+		return appendJSONString(dst, fmt.Sprint(v))
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return appendJSONString(dst, fmt.Sprint(value))
+	}
+
+	return append(dst, b...)
+}
+
+// appendJSONFloat appends a JSON number to dst. NaN and infinities are written as strings.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - f - number to append
+//   - bits - precision of the number (32 or 64)
+func appendJSONFloat(dst []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return appendJSONString(dst, strconv.FormatFloat(f, 'g', -1, bits))
+	}
+
+	return strconv.AppendFloat(dst, f, 'g', -1, bits)
+}
@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsFromArgs(t *testing.T) {
+	got := fieldsFromArgs([]any{"user_id", 42, Bool("admin", true), 3.5, "dangling"})
+	require.Equal(t, []Field{
+		{Key: "user_id", Value: 42},
+		{Key: "admin", Value: true},
+		{Key: badKey, Value: 3.5},
+		{Key: badKey, Value: "dangling"},
+	}, got)
+
+	require.Nil(t, fieldsFromArgs(nil))
+}
+
+// point is a JSON marshaler with a pointer receiver
+type point struct {
+	x, y int
+}
+
+func (p *point) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("[%d,%d]", p.x, p.y)), nil
+}
+
+func TestAppendJSONValue(t *testing.T) {
+	testCases := []struct {
+		value any
+		want  string
+	}{
+		{nil, `null`},
+		{"text \"quoted\"", `"text \"quoted\""`},
+		{true, `true`},
+		{-42, `-42`},
+		{uint8(7), `7`},
+		{1.5, `1.5`},
+		{math.NaN(), `"NaN"`},
+		{math.Inf(1), `"+Inf"`},
+		{1500 * time.Millisecond, `"1.5s"`},
+		{time.Date(2025, 6, 17, 18, 17, 42, 16000000, time.UTC), `"2025-06-17T18:17:42.016Z"`},
+		{errors.New("boom"), `{"msg":"boom","type":"*errors.errorString"}`},
+		{[]int{1, 2}, `[1,2]`},
+		{map[string]int{"a": 1}, `{"a":1}`},
+		{&point{1, 2}, `[1,2]`},
+		{(*point)(nil), `null`},
+	}
+
+	for _, tc := range testCases {
+		got := string(appendJSONValue(nil, tc.value))
+		require.Equal(t, tc.want, got, "value %#v", tc.value)
+	}
+
+	// Values which can't be marshaled are written as strings
+	got := appendJSONValue(nil, func() {})
+	require.Equal(t, byte('"'), got[0])
+}
+
+func TestLogging_Infow(t *testing.T) {
+	ctx := context.WithValue(context.Background(), CtxKeyUUID, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	testCases := []struct {
+		encoder Encoder
+		want    string
+	}{
+		{TextEncoder{}, "INF\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tuser logged in\tuser_id=42 ip=\"10.0.0.1 (proxy)\" admin=false\n"},
+		{JSONEncoder{}, `{"level":"info","uuid":"4577c272-e9b8-4a19-a9d0-4ec0bde6063f","msg":"user logged in","user_id":42,"ip":"10.0.0.1 (proxy)","admin":false}` + "\n"},
+		{LogfmtEncoder{}, "level=info uuid=4577c272-e9b8-4a19-a9d0-4ec0bde6063f msg=\"user logged in\" user_id=42 ip=\"10.0.0.1 (proxy)\" admin=false\n"},
+	}
+
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		logger := &Logging{
+			UUID:    "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+			Output:  &buf,
+			Encoder: tc.encoder,
+		}
+
+		logger.Infow(ctx, "user logged in", "user_id", 42, "ip", "10.0.0.1 (proxy)", Bool("admin", false))
+		require.Equal(t, tc.want, buf.String())
+	}
+}
+
+func ExampleLogging_Infow() {
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"}
+
+	ctx := context.WithValue(context.Background(), CtxKeyUUID, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	logger.Infow("service started", "port", 8080)
+	logger.Errorw(ctx, "request failed", Err(errors.New("timeout")), Duration("latency", 1500*time.Millisecond))
+
+	// Output:
+	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	service started	port=8080
+	// ERR	[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]	request failed	error=timeout latency=1.5s
+}
@@ -0,0 +1,21 @@
+module github.com/ra-company/logging/grpclogging
+
+go 1.24.5
+
+require (
+	github.com/google/uuid v1.6.0
+	github.com/ra-company/logging v0.0.0-20261018092658-40be47683a36
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/grpc v1.80.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.49.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.33.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,273 @@
+// Package grpclogging provides gRPC interceptors which move the process UUID
+// between the context and gRPC metadata and log RPCs through logging.Logging.
+// It is a separate module, so the logging module doesn't depend on gRPC.
+package grpclogging
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ra-company/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMetadataKey is the default metadata key with the process UUID
+const DefaultMetadataKey = "x-request-id"
+
+// Options configures the interceptors.
+type Options struct {
+	Logger      *logging.Logging                                   // Logger for RPC entries (default logging.Logs)
+	MetadataKey string                                             // Metadata key with the process UUID (default "x-request-id")
+	Level       func(method string, code codes.Code) logging.Level // Level of RPC entries (default CodeLevel)
+}
+
+// withDefaults returns options with default values set
+func (opts Options) withDefaults() Options {
+	if opts.Logger == nil {
+		opts.Logger = &logging.Logs
+	}
+	if opts.MetadataKey == "" {
+		opts.MetadataKey = DefaultMetadataKey
+	}
+	if opts.Level == nil {
+		opts.Level = CodeLevel
+	}
+
+	return opts
+}
+
+// CodeLevel returns the level of an RPC entry by the status code:
+// LevelInfo for OK, LevelWarn for errors caused by the client
+// and LevelError for server errors.
+//
+// Parameters:
+//   - method - full RPC method name
+//   - code - status code
+func CodeLevel(method string, code codes.Code) logging.Level {
+	switch code {
+	case codes.OK:
+		return logging.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists,
+		codes.PermissionDenied, codes.Unauthenticated, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return logging.LevelWarn
+	default:
+		return logging.LevelError
+	}
+}
+
+// UnaryServerInterceptor returns a server interceptor which takes the process UUID from the incoming metadata
+// or generates a new one, stores it in the context by logging.ContextWithUUID and logging.CtxKeyUUID,
+// sends it back in the response header and logs the RPC.
+//
+// Parameters:
+//   - opts - options
+func UnaryServerInterceptor(opts Options) grpc.UnaryServerInterceptor {
+	opts = opts.withDefaults()
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		ctx, id := serverContext(ctx, opts.MetadataKey)
+		grpc.SetHeader(ctx, metadata.Pairs(opts.MetadataKey, id))
+
+		resp, err := handler(ctx, req)
+		logRPC(ctx, opts, "grpc request", info.FullMethod, peerAddr(ctx), start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor for streams, see UnaryServerInterceptor.
+//
+// Parameters:
+//   - opts - options
+func StreamServerInterceptor(opts Options) grpc.StreamServerInterceptor {
+	opts = opts.withDefaults()
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		ctx, id := serverContext(ss.Context(), opts.MetadataKey)
+		ss.SetHeader(metadata.Pairs(opts.MetadataKey, id))
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logRPC(ctx, opts, "grpc request", info.FullMethod, peerAddr(ctx), start, err)
+
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor which sends the process UUID of the context
+// in the outgoing metadata and logs the RPC.
+//
+// Parameters:
+//   - opts - options
+func UnaryClientInterceptor(opts Options) grpc.UnaryClientInterceptor {
+	opts = opts.withDefaults()
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		start := time.Now()
+
+		ctx = clientContext(ctx, opts.MetadataKey)
+		var p peer.Peer
+		err := invoker(ctx, method, req, reply, cc, append(callOpts, grpc.Peer(&p))...)
+
+		var addr string
+		if p.Addr != nil {
+			addr = p.Addr.String()
+		}
+		logRPC(ctx, opts, "grpc client request", method, addr, start, err)
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor for streams, see UnaryClientInterceptor.
+// The RPC is logged when the stream is finished.
+//
+// Parameters:
+//   - opts - options
+func StreamClientInterceptor(opts Options) grpc.StreamClientInterceptor {
+	opts = opts.withDefaults()
+
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+
+		ctx = clientContext(ctx, opts.MetadataKey)
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			logRPC(ctx, opts, "grpc client request", method, "", start, err)
+			return nil, err
+		}
+
+		return &clientStream{
+			ClientStream: cs,
+			desc:         desc,
+			finish: func(err error) {
+				logRPC(ctx, opts, "grpc client request", method, peerAddr(cs.Context()), start, err)
+			},
+		}, nil
+	}
+}
+
+// serverContext returns a context with the process UUID from the incoming metadata
+// or a new random UUID if the metadata has no valid UUID.
+//
+// Parameters:
+//   - ctx - context of the RPC
+//   - key - metadata key
+func serverContext(ctx context.Context, key string) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if !logging.ValidRequestID(id) {
+		id = uuid.New().String()
+	}
+
+	// CtxKeyUUID is set too for code which reads it directly
+	return context.WithValue(logging.ContextWithUUID(ctx, id), logging.CtxKeyUUID, id), id
+}
+
+// clientContext returns a context with the process UUID added to the outgoing metadata
+// unless the metadata already has it.
+//
+// Parameters:
+//   - ctx - context of the RPC
+//   - key - metadata key
+func clientContext(ctx context.Context, key string) context.Context {
+	id, ok := logging.UUIDFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(key)) > 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, key, id)
+}
+
+// logRPC writes an RPC entry with method, code, duration, peer and error fields.
+//
+// Parameters:
+//   - ctx - context with the process UUID
+//   - opts - options
+//   - msg - message
+//   - method - full RPC method name
+//   - addr - peer address (empty if unknown)
+//   - start - start time of the RPC
+//   - err - RPC error
+func logRPC(ctx context.Context, opts Options, msg, method, addr string, start time.Time, err error) {
+	code := status.Code(err)
+
+	args := []any{ctx, msg, "method", method, "code", code.String(), logging.Duration("duration", time.Since(start))}
+	if addr != "" {
+		args = append(args, "peer", addr)
+	}
+	if err != nil {
+		args = append(args, logging.Err(err))
+	}
+
+	opts.Logger.Logw(opts.Level(method, code), args...)
+}
+
+// peerAddr returns the peer address stored in the context or an empty string.
+//
+// Parameters:
+//   - ctx - context
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+
+	return ""
+}
+
+// serverStream is a server stream with the context which carries the process UUID
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// clientStream is a client stream which calls finish when the stream is finished
+type clientStream struct {
+	grpc.ClientStream
+	desc   *grpc.StreamDesc
+	once   sync.Once
+	finish func(err error)
+}
+
+// RecvMsg receives a message and detects the end of the stream.
+//
+// Parameters:
+//   - m - message to receive into
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.once.Do(func() { s.finish(nil) })
+	case err != nil:
+		s.once.Do(func() { s.finish(err) })
+	case !s.desc.ServerStreams:
+		// streams without server streaming have one response
+		s.once.Do(func() { s.finish(nil) })
+	}
+
+	return err
+}
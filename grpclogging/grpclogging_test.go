@@ -0,0 +1,210 @@
+package grpclogging
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ra-company/logging"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// syncBuffer is a buffer which can be written by the server and read by the test concurrently
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func (b *syncBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf.Reset()
+}
+
+// idServer is a health server which records the process UUID of the last call
+type idServer struct {
+	*health.Server
+	mu sync.Mutex
+	id string
+}
+
+func (s *idServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.record(ctx)
+
+	return s.Server.Check(ctx, req)
+}
+
+func (s *idServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	s.record(stream.Context())
+
+	return s.Server.Watch(req, stream)
+}
+
+func (s *idServer) record(ctx context.Context) {
+	id, _ := logging.UUIDFromContext(ctx)
+	oldKey, _ := ctx.Value(logging.CtxKeyUUID).(string)
+	if id != oldKey {
+		id = "mismatch"
+	}
+
+	s.mu.Lock()
+	s.id = id
+	s.mu.Unlock()
+}
+
+func (s *idServer) lastID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.id
+}
+
+// startServer starts an in-process server with the interceptors and returns a client connected to it
+func startServer(t *testing.T, srv *idServer, serverLog, clientLog *logging.Logging) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(Options{Logger: serverLog})),
+		grpc.StreamInterceptor(StreamServerInterceptor(Options{Logger: serverLog})),
+	)
+	healthpb.RegisterHealthServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(Options{Logger: clientLog})),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(Options{Logger: clientLog})),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	var serverBuf, clientBuf syncBuffer
+	serverLog := &logging.Logging{UUID: "server", Output: &serverBuf}
+	clientLog := &logging.Logging{UUID: "client", Output: &clientBuf}
+
+	srv := &idServer{Server: health.NewServer()}
+	client := healthpb.NewHealthClient(startServer(t, srv, serverLog, clientLog))
+
+	// The UUID of the context is sent to the server and echoed back
+	ctx := logging.ContextWithUUID(context.Background(), "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	var header metadata.MD
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f", srv.lastID())
+	require.Equal(t, []string{"4577c272-e9b8-4a19-a9d0-4ec0bde6063f"}, header.Get(DefaultMetadataKey))
+
+	require.Regexp(t, regexp.MustCompile(`^INF\t\[4577c272-e9b8-4a19-a9d0-4ec0bde6063f\]\tgrpc request\tmethod=/grpc.health.v1.Health/Check code=OK duration=\S+ peer=bufconn\n$`), serverBuf.String())
+	require.Regexp(t, regexp.MustCompile(`^INF\t\[4577c272-e9b8-4a19-a9d0-4ec0bde6063f\]\tgrpc client request\tmethod=/grpc.health.v1.Health/Check code=OK duration=\S+ peer=bufconn\n$`), clientBuf.String())
+
+	// Errors are logged with the code, a new UUID is generated if the client has none
+	serverBuf.Reset()
+	clientBuf.Reset()
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "missing"}, grpc.Header(&header))
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	id := srv.lastID()
+	require.NoError(t, uuid.Validate(id))
+	require.Equal(t, []string{id}, header.Get(DefaultMetadataKey))
+	require.True(t, strings.HasPrefix(serverBuf.String(), "WRN\t["+id+"]\tgrpc request\tmethod=/grpc.health.v1.Health/Check code=NotFound "), serverBuf.String())
+	require.Contains(t, serverBuf.String(), ` error="rpc error: code = NotFound desc = unknown service"`)
+	require.True(t, strings.HasPrefix(clientBuf.String(), "WRN\t[client]\tgrpc client request\tmethod=/grpc.health.v1.Health/Check code=NotFound "), clientBuf.String())
+
+	// Metadata set by the caller is not overwritten, malformed IDs are replaced
+	for _, md := range []string{"job-1", "bad id"} {
+		ctx = metadata.AppendToOutgoingContext(logging.ContextWithUUID(context.Background(), "ignored"), DefaultMetadataKey, md)
+		_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		if md == "job-1" {
+			require.Equal(t, "job-1", srv.lastID())
+		} else {
+			require.NoError(t, uuid.Validate(srv.lastID()))
+		}
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	var serverBuf, clientBuf syncBuffer
+	serverLog := &logging.Logging{UUID: "server", Output: &serverBuf}
+	clientLog := &logging.Logging{UUID: "client", Output: &clientBuf}
+
+	srv := &idServer{Server: health.NewServer()}
+	client := healthpb.NewHealthClient(startServer(t, srv, serverLog, clientLog))
+
+	ctx, cancel := context.WithCancel(logging.ContextWithUUID(context.Background(), "stream-1"))
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	require.Equal(t, "stream-1", srv.lastID())
+
+	header, err := stream.Header()
+	require.NoError(t, err)
+	require.Equal(t, []string{"stream-1"}, header.Get(DefaultMetadataKey))
+
+	// Nothing is logged until the stream is finished
+	require.Empty(t, clientBuf.String())
+	require.Empty(t, serverBuf.String())
+
+	cancel()
+	_, err = stream.Recv()
+	require.Equal(t, codes.Canceled, status.Code(err))
+
+	require.True(t, strings.HasPrefix(clientBuf.String(), "WRN\t[stream-1]\tgrpc client request\tmethod=/grpc.health.v1.Health/Watch code=Canceled "), clientBuf.String())
+	require.Eventually(t, func() bool {
+		return strings.HasPrefix(serverBuf.String(), "WRN\t[stream-1]\tgrpc request\tmethod=/grpc.health.v1.Health/Watch code=Canceled ")
+	}, time.Second, 10*time.Millisecond, serverBuf.String())
+
+	// A finished stream is logged once
+	_, err = stream.Recv()
+	require.Error(t, err)
+	require.Equal(t, 1, strings.Count(clientBuf.String(), "\n"))
+}
+
+func TestCodeLevel(t *testing.T) {
+	require.Equal(t, logging.LevelInfo, CodeLevel("/m", codes.OK))
+	require.Equal(t, logging.LevelWarn, CodeLevel("/m", codes.NotFound))
+	require.Equal(t, logging.LevelWarn, CodeLevel("/m", codes.Canceled))
+	require.Equal(t, logging.LevelError, CodeLevel("/m", codes.Internal))
+	require.Equal(t, logging.LevelError, CodeLevel("/m", codes.Unknown))
+}
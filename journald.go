@@ -0,0 +1,220 @@
+package logging
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// journaldSocket is the default path of the journald native protocol socket
+const journaldSocket = "/run/systemd/journal/socket"
+
+// JournaldSink is a Sink which sends entries to systemd-journald using the native protocol.
+//
+// Every entry has the MESSAGE, PRIORITY, SYSLOG_IDENTIFIER and PROCESS_UUID fields,
+// so entries can be filtered with `journalctl PROCESS_UUID=...`.
+// The caller is sent as CODE_FILE, CODE_LINE and CODE_FUNC if ShowCaller is set.
+// The stack trace is sent as STACK.
+// Structured fields are sent as journal fields with uppercase names,
+// errors are sent as ERROR, ERROR_TYPE, ERROR_CAUSES and ERROR_<attribute> fields.
+// Fields which would clash with fields set by the sink or by journald, like "priority" or "message",
+// get the FIELD_ prefix. Entries too large for a datagram are passed in a temporary file on Unix systems.
+type JournaldSink struct {
+	Socket     string // Socket path (default "/run/systemd/journal/socket")
+	Identifier string // SYSLOG_IDENTIFIER (default title passed to Starting or the executable name)
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// journalReserved is a set of journal fields set by the sink or having a special meaning for journald
+var journalReserved = map[string]bool{
+	"MESSAGE":           true,
+	"MESSAGE_ID":        true,
+	"PRIORITY":          true,
+	"SYSLOG_IDENTIFIER": true,
+	"SYSLOG_FACILITY":   true,
+	"SYSLOG_PID":        true,
+	"SYSLOG_TIMESTAMP":  true,
+	"SYSLOG_RAW":        true,
+	"PROCESS_UUID":      true,
+	"LOGGER":            true,
+	"CODE_FILE":         true,
+	"CODE_LINE":         true,
+	"CODE_FUNC":         true,
+	"STACK":             true,
+	"ERRNO":             true,
+	"TID":               true,
+	"DOCUMENTATION":     true,
+}
+
+// JournaldAvailable reports whether the journald socket exists.
+func JournaldAvailable() bool {
+	_, err := os.Stat(journaldSocket)
+
+	return err == nil
+}
+
+// WriteEntry sends an entry to journald, reconnecting once if sending fails.
+//
+// Parameters:
+//   - e - entry to send
+func (j *JournaldSink) WriteEntry(e *Entry) error {
+	msg := j.format(e)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if j.conn == nil {
+			socket := j.Socket
+			if socket == "" {
+				socket = journaldSocket
+			}
+			if j.conn, err = net.Dial("unixgram", socket); err != nil {
+				j.conn = nil
+				continue
+			}
+		}
+
+		if _, err = j.conn.Write(msg); err == nil {
+			return nil
+		}
+		if journalTooLarge(err) {
+			return sendJournalFile(j.conn, msg)
+		}
+
+		j.conn.Close()
+		j.conn = nil
+	}
+
+	return err
+}
+
+// Close closes the connection.
+func (j *JournaldSink) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.conn == nil {
+		return nil
+	}
+
+	err := j.conn.Close()
+	j.conn = nil
+
+	return err
+}
+
+// format serializes an entry in the journald native protocol.
+//
+// Parameters:
+//   - e - entry to serialize
+func (j *JournaldSink) format(e *Entry) []byte {
+	identifier := j.Identifier
+	if identifier == "" {
+		identifier = e.Title
+	}
+	if identifier == "" {
+		identifier = filepath.Base(os.Args[0])
+	}
+
+	msg := e.Message
+	if e.Logger != "" {
+		msg = e.Logger + ": " + msg
+	}
+
+	var b []byte
+	b = appendJournalField(b, "MESSAGE", msg)
+	b = appendJournalField(b, "PRIORITY", strconv.Itoa(syslogSeverity(e.Level)))
+	b = appendJournalField(b, "SYSLOG_IDENTIFIER", identifier)
+	if e.UUID != "" {
+		b = appendJournalField(b, "PROCESS_UUID", e.UUID)
+	}
+	if e.Logger != "" {
+		b = appendJournalField(b, "LOGGER", e.Logger)
+	}
+	if e.Caller.Defined() {
+		b = appendJournalField(b, "CODE_FILE", e.Caller.File)
+		b = appendJournalField(b, "CODE_LINE", strconv.Itoa(e.Caller.Line))
+		if e.Caller.Function != "" {
+			b = appendJournalField(b, "CODE_FUNC", e.Caller.Function)
+		}
+	}
+	if e.Stack != "" {
+		b = appendJournalField(b, "STACK", e.Stack)
+	}
+	var fields []Field
+	if e.Error != nil {
+		fields = errorFields("error", e.Error, true)
+	}
+	for _, f := range e.Fields {
+		if err, ok := f.Value.(error); ok {
+			fields = append(fields, errorFields(f.Key, err, true)...)
+		} else {
+			fields = append(fields, f)
+		}
+	}
+	for _, f := range fields {
+		if key := journalKey(f.Key); key != "" {
+			if journalReserved[key] {
+				key = "FIELD_" + key
+			}
+			b = appendJournalField(b, key, fieldString(f.Value))
+		}
+	}
+
+	return b
+}
+
+// appendJournalField appends a field in the journald native protocol.
+// Values with newlines are written with an explicit little-endian length.
+//
+// Parameters:
+//   - dst - buffer to append to
+//   - key - field name
+//   - value - field value
+func appendJournalField(dst []byte, key, value string) []byte {
+	dst = append(dst, key...)
+	if !strings.ContainsRune(value, '\n') {
+		dst = append(dst, '=')
+		dst = append(dst, value...)
+		return append(dst, '\n')
+	}
+
+	dst = append(dst, '\n')
+	dst = binary.LittleEndian.AppendUint64(dst, uint64(len(value)))
+	dst = append(dst, value...)
+
+	return append(dst, '\n')
+}
+
+// journalKey converts a field name to a journal field name: uppercase letters, digits and underscores,
+// not starting with a digit or an underscore. Empty string is returned if the name can't be converted.
+//
+// Parameters:
+//   - key - field name
+func journalKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return '_'
+		}
+	}, key)
+
+	key = strings.TrimLeft(key, "_0123456789")
+	if len(key) > 64 {
+		key = key[:64]
+	}
+
+	return key
+}
@@ -0,0 +1,26 @@
+//go:build !unix
+
+package logging
+
+import (
+	"errors"
+	"net"
+)
+
+// journalTooLarge reports whether a datagram was rejected because of its size.
+// Descriptors can't be passed on this platform, so large entries aren't retried.
+//
+// Parameters:
+//   - err - write error
+func journalTooLarge(err error) bool {
+	return false
+}
+
+// sendJournalFile is not supported on this platform.
+//
+// Parameters:
+//   - conn - journald connection
+//   - msg - serialized entry
+func sendJournalFile(conn net.Conn, msg []byte) error {
+	return errors.New("logging: passing descriptors to journald is not supported")
+}
@@ -0,0 +1,114 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// parseJournal parses a datagram in the journald native protocol
+func parseJournal(t *testing.T, b []byte) map[string]string {
+	fields := map[string]string{}
+	for len(b) > 0 {
+		i := bytes.IndexAny(b, "=\n")
+		require.GreaterOrEqual(t, i, 0)
+
+		key := string(b[:i])
+		if b[i] == '=' {
+			end := bytes.IndexByte(b, '\n')
+			fields[key] = string(b[i+1 : end])
+			b = b[end+1:]
+			continue
+		}
+
+		size := binary.LittleEndian.Uint64(b[i+1 : i+9])
+		fields[key] = string(b[i+9 : i+9+int(size)])
+		b = b[i+9+int(size)+1:]
+	}
+
+	return fields
+}
+
+func TestJournaldSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink := &JournaldSink{Socket: path}
+	defer sink.Close()
+
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Sink: sink}
+	logger.Starting("billing")
+	logger.Named("invoices").Errorw("payment failed\nsecond line", "order_id", 17, "1st", "x")
+
+	buf := make([]byte, 4096)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"MESSAGE":           "billing service is starting...",
+		"PRIORITY":          "6",
+		"SYSLOG_IDENTIFIER": "billing",
+		"PROCESS_UUID":      "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+	}, parseJournal(t, buf[:n]))
+
+	n, err = conn.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"MESSAGE":           "invoices: payment failed\nsecond line",
+		"PRIORITY":          "3",
+		"SYSLOG_IDENTIFIER": "billing",
+		"PROCESS_UUID":      "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		"LOGGER":            "invoices",
+		"ORDER_ID":          "17",
+		"ST":                "x",
+	}, parseJournal(t, buf[:n]))
+
+	logger.SetShowCaller(true)
+	logger.SetShowFunction(true)
+	want := line() + 1
+	logger.Warn("with caller")
+
+	n, err = conn.Read(buf)
+	require.NoError(t, err)
+	fields := parseJournal(t, buf[:n])
+	require.Equal(t, "journald_test.go", filepath.Base(fields["CODE_FILE"]))
+	require.Equal(t, strconv.Itoa(want), fields["CODE_LINE"])
+	require.Equal(t, "github.com/ra-company/logging.TestJournaldSink", fields["CODE_FUNC"])
+
+	// Reserved names get a prefix, the stack trace is sent
+	logger.SetShowCaller(false)
+	logger.SetStack(true, LevelError, 0)
+	logger.Errorw("clash", "priority", "high", "Message", "m")
+
+	n, err = conn.Read(buf)
+	require.NoError(t, err)
+	fields = parseJournal(t, buf[:n])
+	require.Equal(t, "3", fields["PRIORITY"])
+	require.Equal(t, "clash", fields["MESSAGE"])
+	require.Equal(t, "high", fields["FIELD_PRIORITY"])
+	require.Equal(t, "m", fields["FIELD_MESSAGE"])
+	require.Contains(t, fields["STACK"], "logging.TestJournaldSink")
+}
+
+func TestJournalKey(t *testing.T) {
+	testCases := map[string]string{
+		"user_id":   "USER_ID",
+		"http.path": "HTTP_PATH",
+		"_private":  "PRIVATE",
+		"9lives":    "LIVES",
+		"___":       "",
+	}
+
+	for key, want := range testCases {
+		require.Equal(t, want, journalKey(key), "journalKey(%q)", key)
+	}
+}
@@ -0,0 +1,65 @@
+//go:build unix
+
+package logging
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+)
+
+// journalTooLarge reports whether a datagram was rejected because of its size.
+//
+// Parameters:
+//   - err - write error
+func journalTooLarge(err error) bool {
+	return errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS)
+}
+
+// sendJournalFile writes an entry to an unlinked temporary file and passes its descriptor to journald.
+// It is used for entries too large for a datagram.
+//
+// Parameters:
+//   - conn - journald connection
+//   - msg - serialized entry
+func sendJournalFile(conn net.Conn, msg []byte) error {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return syscall.EMSGSIZE
+	}
+
+	dir := "/dev/shm"
+	if _, err := os.Stat(dir); err != nil {
+		dir = os.TempDir()
+	}
+
+	f, err := os.CreateTemp(dir, "journal-")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	os.Remove(f.Name())
+
+	if _, err := f.Write(msg); err != nil {
+		return err
+	}
+
+	rc, err := uc.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	// WriteMsgUnix can't be used with a connected datagram socket
+	rights := syscall.UnixRights(int(f.Fd()))
+	var sendErr error
+	err = rc.Write(func(fd uintptr) bool {
+		sendErr = syscall.Sendmsg(int(fd), nil, rights, nil, 0)
+		return sendErr != syscall.EAGAIN
+	})
+	if err != nil {
+		return err
+	}
+
+	return sendErr
+}
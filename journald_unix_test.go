@@ -0,0 +1,50 @@
+//go:build unix
+
+package logging
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournaldSink_Large(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink := &JournaldSink{Socket: path, Identifier: "app"}
+	defer sink.Close()
+
+	// An entry larger than a datagram is passed in a file
+	large := strings.Repeat("x", 4<<20)
+	require.NoError(t, sink.WriteEntry(&Entry{Level: LevelInfo, Message: large}))
+
+	oob := make([]byte, syscall.CmsgSpace(4))
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, oobn, _, _, err := conn.ReadMsgUnix(nil, oob)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	require.NoError(t, err)
+	require.Len(t, fds, 1)
+
+	f := os.NewFile(uintptr(fds[0]), "journal")
+	defer f.Close()
+	f.Seek(0, io.SeekStart)
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, large, parseJournal(t, data)["MESSAGE"])
+}
@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Level is a log level. Levels are ordered by severity, so a logger with a minimal
+// level prints entries with the same or a higher level.
+type Level int8
+
+const (
+	LevelTrace Level = iota - 1 // Trace messages (TRC)
+	LevelDebug                  // Debug messages (DBG)
+	LevelInfo                   // Informational messages (INF)
+	LevelWarn                   // Warnings (WRN)
+	LevelError                  // Errors (ERR)
+	LevelFatal                  // Fatal errors, the process exits (FTL)
+	LevelPanic                  // Panics, the logger panics after printing (PNC)
+)
+
+var (
+	levelNames  = [...]string{"trace", "debug", "info", "warn", "error", "fatal", "panic"}
+	levelLabels = [...]string{"TRC", "DBG", "INF", "WRN", "ERR", "FTL", "PNC"}
+
+	// legacyLevels maps old integer levels to Level values
+	legacyLevels = [...]Level{LevelDebug, LevelWarn, LevelError, LevelFatal, LevelInfo}
+)
+
+// String returns the lowercase level name (trace, debug, info, warn, error, fatal or panic).
+func (l Level) String() string {
+	if l < LevelTrace || l > LevelPanic {
+		return "Level(" + strconv.Itoa(int(l)) + ")"
+	}
+
+	return levelNames[l-LevelTrace]
+}
+
+// Label returns the short level label used in text output (TRC, DBG, INF, WRN, ERR, FTL or PNC).
+func (l Level) Label() string {
+	if l < LevelTrace || l > LevelPanic {
+		return "L" + strconv.Itoa(int(l))
+	}
+
+	return levelLabels[l-LevelTrace]
+}
+
+// Int returns the old integer level (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info).
+// Trace is converted to debug and panic to fatal.
+func (l Level) Int() int {
+	switch {
+	case l <= LevelDebug:
+		return 0
+	case l == LevelInfo:
+		return 4
+	case l == LevelWarn:
+		return 1
+	case l == LevelError:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	if l < LevelTrace || l > LevelPanic {
+		return nil, fmt.Errorf("unknown log level %d", l)
+	}
+
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+//
+// Parameters:
+//   - text - level name
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+
+	*l = level
+
+	return nil
+}
+
+// ParseLevel converts a level name or label to a Level. The case is ignored.
+//
+// Parameters:
+//   - name - level name (trace, debug, info, warn, warning, error, fatal, panic)
+//     or label (TRC, DBG, INF, WRN, ERR, FTL, PNC)
+//
+// Returns:
+//   - Level: parsed level
+//   - error: error if the name is unknown
+func ParseLevel(name string) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		return LevelWarn, nil
+	}
+
+	for i := range levelNames {
+		if name == levelNames[i] || name == strings.ToLower(levelLabels[i]) {
+			return Level(i) + LevelTrace, nil
+		}
+	}
+
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// LevelFromInt converts an old integer level to a Level.
+// Unknown values are converted to info level.
+//
+// Parameters:
+//   - level - log level (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info)
+func LevelFromInt(level int) Level {
+	if level < 0 || level >= len(legacyLevels) {
+		return LevelInfo
+	}
+
+	return legacyLevels[level]
+}
@@ -0,0 +1,208 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LevelHandler is an http.Handler which reports the log level on GET
+// and changes it on PUT or POST.
+//
+// The new level is taken from the "level" query or form parameter or from a JSON body
+// like {"level": "debug", "revert": "10m"}. Level names accepted by ParseLevel
+// and old integer levels are accepted. If "revert" is set, the previous level is restored after that duration.
+type LevelHandler struct {
+	logger   *Logging
+	mu       sync.Mutex
+	timer    *time.Timer // Pending revert
+	revertTo Level       // Level restored by the pending revert
+}
+
+// levelRequest is a body of PUT and POST requests
+type levelRequest struct {
+	Level  json.RawMessage `json:"level"`
+	Revert string          `json:"revert,omitempty"`
+}
+
+// levelResponse is a body of responses
+type levelResponse struct {
+	Level  string `json:"level,omitempty"`
+	Value  *int   `json:"value,omitempty"`
+	Revert string `json:"revert,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// NewLevelHandler creates a handler which controls the log level of a logger.
+// The global Logs instance is used if logger is nil.
+//
+// Parameters:
+//   - logger - logger to control
+func NewLevelHandler(logger *Logging) *LevelHandler {
+	if logger == nil {
+		logger = &Logs
+	}
+
+	return &LevelHandler{logger: logger}
+}
+
+// ServeHTTP handles requests to read or change the log level.
+//
+// Parameters:
+//   - w - response writer
+//   - r - request
+func (h *LevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.respond(w, http.StatusOK, h.logger.Level(), 0)
+	case http.MethodPut, http.MethodPost:
+		level, revert, err := parseLevelRequest(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, levelResponse{Error: err.Error()})
+			return
+		}
+
+		h.setLevel(level, revert)
+		h.respond(w, http.StatusOK, level, revert)
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeJSON(w, http.StatusMethodNotAllowed, levelResponse{Error: "method not allowed"})
+	}
+}
+
+// setLevel changes the log level and schedules the revert if needed.
+// A new change cancels the pending revert, but keeps the level to restore.
+//
+// Parameters:
+//   - level - new log level
+//   - revert - duration after which the previous level is restored (0 - never)
+func (h *LevelHandler) setLevel(level Level, revert time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	revertTo := h.logger.Level()
+	if h.timer != nil {
+		if h.timer.Stop() {
+			revertTo = h.revertTo
+		}
+		h.timer = nil
+	}
+
+	h.logger.SetLevel(level)
+
+	if revert > 0 {
+		h.revertTo = revertTo
+		var timer *time.Timer
+		timer = time.AfterFunc(revert, func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			if h.timer == timer {
+				h.logger.SetLevel(h.revertTo)
+				h.timer = nil
+			}
+		})
+		h.timer = timer
+	}
+}
+
+// respond writes the level in a JSON response.
+//
+// Parameters:
+//   - w - response writer
+//   - status - HTTP status code
+//   - level - log level
+//   - revert - revert duration (0 - not set)
+func (h *LevelHandler) respond(w http.ResponseWriter, status int, level Level, revert time.Duration) {
+	value := level.Int()
+	resp := levelResponse{
+		Level: level.String(),
+		Value: &value,
+	}
+	if revert > 0 {
+		resp.Revert = revert.String()
+	}
+
+	writeJSON(w, status, resp)
+}
+
+// parseLevelRequest reads the new level and revert duration from a request.
+//
+// Parameters:
+//   - r - request
+func parseLevelRequest(r *http.Request) (Level, time.Duration, error) {
+	var level, revert string
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var req levelRequest
+		body, err := io.ReadAll(io.LimitReader(r.Body, 4096))
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return 0, 0, fmt.Errorf("invalid JSON body: %w", err)
+		}
+
+		if err := json.Unmarshal(req.Level, &level); err != nil {
+			level = string(req.Level) // level is a number
+		}
+		revert = req.Revert
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return 0, 0, err
+		}
+		level = r.Form.Get("level")
+		revert = r.Form.Get("revert")
+	}
+
+	if level == "" {
+		return 0, 0, fmt.Errorf("level is not set")
+	}
+
+	lvl, err := parseLevelName(level)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var d time.Duration
+	if revert != "" {
+		d, err = time.ParseDuration(revert)
+		if err != nil || d < 0 {
+			return 0, 0, fmt.Errorf("invalid revert duration %q", revert)
+		}
+	}
+
+	return lvl, d, nil
+}
+
+// parseLevelName converts a level name or an old integer level to a Level.
+//
+// Parameters:
+//   - name - level name or number (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info)
+func parseLevelName(name string) (Level, error) {
+	if n, err := strconv.Atoi(strings.TrimSpace(name)); err == nil {
+		if n < 0 || n > 4 {
+			return 0, fmt.Errorf("unknown log level %q", name)
+		}
+		return LevelFromInt(n), nil
+	}
+
+	return ParseLevel(name)
+}
+
+// writeJSON writes a JSON response.
+//
+// Parameters:
+//   - w - response writer
+//   - status - HTTP status code
+//   - v - value to encode
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
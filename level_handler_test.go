@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelHandler(t *testing.T) {
+	logger := &Logging{LogLevel: 2}
+	h := NewLevelHandler(logger)
+
+	do := func(method, target, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodGet, "/log/level", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"error","value":2}`, rec.Body.String())
+
+	rec = do(http.MethodPut, "/log/level?level=debug", "", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"debug","value":0}`, rec.Body.String())
+	require.Equal(t, 0, logger.GetLogLevel())
+
+	rec = do(http.MethodPost, "/log/level", "application/x-www-form-urlencoded", "level=FATAL")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 3, logger.GetLogLevel())
+
+	rec = do(http.MethodPut, "/log/level", "application/json", `{"level":1}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"warn","value":1}`, rec.Body.String())
+
+	testCases := []struct {
+		contentType string
+		body        string
+	}{
+		{"application/json", `{"level":"verbose"}`},
+		{"application/json", `{"level":7}`},
+		{"application/json", `{}`},
+		{"application/json", `{`},
+		{"application/json", `{"level":"debug","revert":"soon"}`},
+		{"application/x-www-form-urlencoded", ""},
+	}
+
+	for _, tc := range testCases {
+		rec = do(http.MethodPut, "/log/level", tc.contentType, tc.body)
+		require.Equal(t, http.StatusBadRequest, rec.Code, "body %q", tc.body)
+		require.Contains(t, rec.Body.String(), `"error"`)
+	}
+	require.Equal(t, 1, logger.GetLogLevel())
+
+	rec = do(http.MethodDelete, "/log/level", "", "")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Equal(t, "GET, PUT, POST", rec.Header().Get("Allow"))
+}
+
+func TestLevelHandler_Revert(t *testing.T) {
+	logger := &Logging{LogLevel: 2}
+	h := NewLevelHandler(logger)
+
+	// The revert is long enough not to fire during the test
+	req := httptest.NewRequest(http.MethodPut, "/log/level", strings.NewReader(`{"level":"debug","revert":"1h"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"debug","value":0,"revert":"1h0m0s"}`, rec.Body.String())
+	require.Equal(t, 0, logger.GetLogLevel())
+
+	// A new change with revert keeps the original level to restore
+	req = httptest.NewRequest(http.MethodPut, "/log/level?level=warn&revert=1h", nil)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, 1, logger.GetLogLevel())
+
+	// The original level is restored when the revert fires
+	h.setLevel(LevelInfo, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return logger.GetLogLevel() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A change without revert cancels the pending revert
+	h.setLevel(LevelDebug, time.Hour)
+	h.setLevel(LevelFatal, 0)
+	require.Equal(t, 3, logger.GetLogLevel())
+
+	h.mu.Lock()
+	require.Nil(t, h.timer)
+	h.mu.Unlock()
+}
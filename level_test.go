@@ -0,0 +1,131 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevel(t *testing.T) {
+	testCases := []struct {
+		level Level
+		name  string
+		label string
+		old   int
+	}{
+		{LevelTrace, "trace", "TRC", 0},
+		{LevelDebug, "debug", "DBG", 0},
+		{LevelInfo, "info", "INF", 4},
+		{LevelWarn, "warn", "WRN", 1},
+		{LevelError, "error", "ERR", 2},
+		{LevelFatal, "fatal", "FTL", 3},
+		{LevelPanic, "panic", "PNC", 3},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.name, tc.level.String())
+		require.Equal(t, tc.label, tc.level.Label())
+		require.Equal(t, tc.old, tc.level.Int())
+
+		parsed, err := ParseLevel(tc.name)
+		require.NoError(t, err)
+		require.Equal(t, tc.level, parsed)
+
+		parsed, err = ParseLevel(tc.label)
+		require.NoError(t, err)
+		require.Equal(t, tc.level, parsed)
+	}
+
+	require.Equal(t, "Level(9)", Level(9).String())
+
+	parsed, err := ParseLevel(" Warning ")
+	require.NoError(t, err)
+	require.Equal(t, LevelWarn, parsed)
+
+	_, err = ParseLevel("verbose")
+	require.Error(t, err)
+
+	require.True(t, LevelTrace < LevelDebug && LevelDebug < LevelInfo && LevelInfo < LevelWarn &&
+		LevelWarn < LevelError && LevelError < LevelFatal && LevelFatal < LevelPanic)
+}
+
+func TestLevel_MarshalText(t *testing.T) {
+	var cfg struct {
+		Level Level `json:"level"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"level":"ERR"}`), &cfg))
+	require.Equal(t, LevelError, cfg.Level)
+
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.Equal(t, `{"level":"error"}`, string(b))
+
+	require.Error(t, json.Unmarshal([]byte(`{"level":"loud"}`), &cfg))
+
+	_, err = Level(42).MarshalText()
+	require.Error(t, err)
+}
+
+func TestLevelFromInt(t *testing.T) {
+	testCases := map[int]Level{
+		-1: LevelInfo,
+		0:  LevelDebug,
+		1:  LevelWarn,
+		2:  LevelError,
+		3:  LevelFatal,
+		4:  LevelInfo,
+		5:  LevelInfo,
+	}
+
+	for old, want := range testCases {
+		require.Equal(t, want, LevelFromInt(old), "LevelFromInt(%d)", old)
+	}
+}
+
+func TestLogging_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{
+		UUID:   "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Output: &buf,
+	}
+
+	logger.SetLevel(LevelTrace)
+	require.Equal(t, LevelTrace, logger.Level())
+	require.Equal(t, 0, logger.GetLogLevel())
+
+	logger.Trace("trace")
+	logger.Debug("debug")
+
+	logger.SetLevel(LevelWarn)
+	require.Equal(t, 1, logger.GetLogLevel())
+
+	logger.Info("hidden")
+	logger.Warn("warn")
+
+	// Direct assignment of the old field takes precedence
+	logger.LogLevel = 4
+	require.Equal(t, LevelInfo, logger.Level())
+
+	logger.Debug("hidden")
+	logger.Info("info")
+	logger.Error("error")
+
+	logger.SetLogLevel(2)
+	require.Equal(t, LevelError, logger.Level())
+	logger.Log(LevelPanic, "custom")
+
+	want := "TRC\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\ttrace\n" +
+		"DBG\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tdebug\n" +
+		"WRN\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\twarn\n" +
+		"INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tinfo\n" +
+		"ERR\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\terror\n" +
+		"PNC\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tcustom\n"
+	require.Equal(t, want, buf.String())
+
+	require.PanicsWithValue(t, "boom 42", func() {
+		logger.Panicf("boom %d", 42)
+	})
+}
@@ -1,5 +1,10 @@
 package logging
 
+import (
+	"context"
+	"fmt"
+)
+
 // Logger Interface defines the methods for logging at different levels.
 type Logger interface {
 	// Debug logs a message at Debug level.
@@ -24,6 +29,27 @@ type Logger interface {
 	Fatal(args ...any)
 }
 
+// StructuredLogger is an optional interface for loggers which support structured fields.
+// The arguments are an optional context, a message and fields given as Field values
+// or alternating keys and values.
+type StructuredLogger interface {
+	// Debugw logs a message with fields at Debug level.
+	Debugw(args ...any)
+
+	// Infow logs a message with fields at Info level.
+	Infow(args ...any)
+
+	// Warnw logs a message with fields at Warning level.
+	Warnw(args ...any)
+
+	// Errorw logs a message with fields at Error level.
+	Errorw(args ...any)
+
+	// Fatalw logs a message with fields at Fatal level
+	// and process will exit with status set to 1.
+	Fatalw(args ...any)
+}
+
 // CustomLogger is a wrapper around a Logger interface that allows
 // for custom logging implementations. It provides methods to log messages
 type CustomLogger struct {
@@ -34,9 +60,20 @@ type CustomLogger struct {
 // This is useful when you want to use a different logging mechanism
 // instead of the default one provided by the package.
 //
+// *Logging and *SlogLogger loggers are wrapped to skip the CustomLogger frame,
+// so the caller of CustomLogger methods is reported.
+//
 // Parameters:
 //   - logger: An instance of a type that implements the Logger interface.
 func (dst *CustomLogger) SetLogger(logger Logger) {
+	switch l := logger.(type) {
+	case *Logging:
+		logger = l.AddCallerSkip(1)
+	case *SlogLogger:
+		// options of l set later are still used
+		logger = &SlogLogger{parent: l.root(), callerSkip: l.callerSkip + 1}
+	}
+
 	dst.logger = logger
 }
 
@@ -54,7 +91,7 @@ func (dst *CustomLogger) Debug(args ...any) {
 		return
 	}
 
-	Logs.Debugf(args...)
+	Logs.log(1, LevelDebug, printFormat, args)
 }
 
 // Info logs an informational message using the provided logger or the default logging mechanism.
@@ -70,7 +107,7 @@ func (dst *CustomLogger) Info(args ...any) {
 		return
 	}
 
-	Logs.Infof(args...)
+	Logs.log(1, LevelInfo, printFormat, args)
 }
 
 // Warn logs a warning message using the provided logger or the default logging mechanism.
@@ -86,7 +123,7 @@ func (dst *CustomLogger) Warn(args ...any) {
 		return
 	}
 
-	Logs.Warnf(args...)
+	Logs.log(1, LevelWarn, printFormat, args)
 }
 
 // Error logs an error message using the provided logger or the default logging mechanism.
@@ -102,7 +139,7 @@ func (dst *CustomLogger) Error(args ...any) {
 		return
 	}
 
-	Logs.Errorf(args...)
+	Logs.log(1, LevelError, printFormat, args)
 }
 
 // Fatal logs a fatal error message using the provided logger or the default logging mechanism.
@@ -118,5 +155,143 @@ func (dst *CustomLogger) Fatal(args ...any) {
 		return
 	}
 
-	Logs.Fatalf(args...)
+	Logs.log(1, LevelFatal, printFormat, args)
+	Logs.exit()
+}
+
+// Debugw logs a debug message with structured fields using the provided logger or the default logging mechanism.
+// Loggers which don't implement StructuredLogger receive the message with fields rendered as text.
+//
+// Parameters:
+//   - args: The message and fields to log.
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (dst *CustomLogger) Debugw(args ...any) {
+	if logger, ok := dst.logger.(StructuredLogger); ok {
+		logger.Debugw(args...)
+		return
+	}
+
+	if dst.logger != nil {
+		dst.logger.Debug(flattenFields(args)...)
+		return
+	}
+
+	Logs.log(1, LevelDebug, printFields, args)
+}
+
+// Infow logs an informational message with structured fields using the provided logger or the default logging mechanism.
+// Loggers which don't implement StructuredLogger receive the message with fields rendered as text.
+//
+// Parameters:
+//   - args: The message and fields to log.
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (dst *CustomLogger) Infow(args ...any) {
+	if logger, ok := dst.logger.(StructuredLogger); ok {
+		logger.Infow(args...)
+		return
+	}
+
+	if dst.logger != nil {
+		dst.logger.Info(flattenFields(args)...)
+		return
+	}
+
+	Logs.log(1, LevelInfo, printFields, args)
+}
+
+// Warnw logs a warning message with structured fields using the provided logger or the default logging mechanism.
+// Loggers which don't implement StructuredLogger receive the message with fields rendered as text.
+//
+// Parameters:
+//   - args: The message and fields to log.
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (dst *CustomLogger) Warnw(args ...any) {
+	if logger, ok := dst.logger.(StructuredLogger); ok {
+		logger.Warnw(args...)
+		return
+	}
+
+	if dst.logger != nil {
+		dst.logger.Warn(flattenFields(args)...)
+		return
+	}
+
+	Logs.log(1, LevelWarn, printFields, args)
+}
+
+// Errorw logs an error message with structured fields using the provided logger or the default logging mechanism.
+// Loggers which don't implement StructuredLogger receive the message with fields rendered as text.
+//
+// Parameters:
+//   - args: The message and fields to log.
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (dst *CustomLogger) Errorw(args ...any) {
+	if logger, ok := dst.logger.(StructuredLogger); ok {
+		logger.Errorw(args...)
+		return
+	}
+
+	if dst.logger != nil {
+		dst.logger.Error(flattenFields(args)...)
+		return
+	}
+
+	Logs.log(1, LevelError, printFields, args)
+}
+
+// Fatalw logs a fatal error message with structured fields using the provided logger or the default logging mechanism.
+// Loggers which don't implement StructuredLogger receive the message with fields rendered as text.
+//
+// Parameters:
+//   - args: The message and fields to log.
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (dst *CustomLogger) Fatalw(args ...any) {
+	if logger, ok := dst.logger.(StructuredLogger); ok {
+		logger.Fatalw(args...)
+		return
+	}
+
+	if dst.logger != nil {
+		dst.logger.Fatal(flattenFields(args)...)
+		return
+	}
+
+	Logs.log(1, LevelFatal, printFields, args)
+	Logs.exit()
+}
+
+// flattenFields converts structured arguments to arguments for a Logger
+// by rendering fields as text after the message.
+//
+// Parameters:
+//   - args - an optional context, a message and fields
+func flattenFields(args []any) []any {
+	var out []any
+	if len(args) > 0 {
+		if _, ok := args[0].(context.Context); ok {
+			out = append(out, args[0])
+			args = args[1:]
+		}
+	}
+
+	var msg string
+	if len(args) > 0 {
+		msg = fmt.Sprint(args[0])
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		msg += "\t" + string(appendTextFields(nil, fieldsFromArgs(args)))
+	}
+
+	return append(out, msg)
 }
@@ -98,3 +98,33 @@ func ExampleCustomLogger() {
 	// ERR	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	CustomerLogger without ctx without variable
 	// FTL	[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]	CustomerLogger with ctx and variable: World
 }
+
+// plainLogger implements only the Logger interface.
+type plainLogger struct {
+	logger *Logging
+}
+
+func (l plainLogger) Debug(args ...any) { l.logger.Debug(args...) }
+func (l plainLogger) Info(args ...any)  { l.logger.Info(args...) }
+func (l plainLogger) Warn(args ...any)  { l.logger.Warn(args...) }
+func (l plainLogger) Error(args ...any) { l.logger.Error(args...) }
+func (l plainLogger) Fatal(args ...any) { l.logger.Fatal(args...) }
+
+func ExampleCustomLogger_Infow() {
+	cLog := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"}
+
+	logs := &CustomLogger{}
+	logs.SetLogger(plainLogger{cLog})
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, CtxKeyUUID, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	logs.Infow(ctx, "CustomerLogger with fields", "user_id", 42)
+
+	logs.SetLogger(cLog)
+	logs.Warnw("CustomerLogger with fields", "progress", "100%")
+
+	// Output:
+	// INF	[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]	CustomerLogger with fields	user_id=42
+	// WRN	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	CustomerLogger with fields	progress=100%
+}
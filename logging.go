@@ -3,7 +3,10 @@ package logging
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,50 +17,86 @@ type CtxKey string
 
 var (
 	Logs       Logging
-	CtxKeyUUID CtxKey = "process-uuid" // Context key for process UUID
+	CtxKeyUUID CtxKey = "process-uuid" // Context key for process UUID (ContextWithUUID is preferred)
 )
 
+// Logging writes log entries with the process UUID.
+// Configuration fields may be assigned directly before logging starts,
+// at runtime use setters such as SetLogLevel or SetShowTime instead.
 type Logging struct {
-	UUID       string
-	LogLevel   int    // Log level (0 - debug, 1 - warning, 2 - error, 3 - fatal, default 0)
-	ConsoleApp bool   // Console application flag (do not print logs in console app)
-	ShowTime   bool   // Show time in logs
-	DontStop   bool   // Do not stop service on fatal error
-	title      string // Process title
+	UUID           string
+	LogLevel       int                // Old integer log level (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info, default 0), see SetLevel
+	ConsoleApp     bool               // Console application flag (do not print logs in console app)
+	ShowTime       bool               // Show time in logs
+	DontStop       bool               // Do not stop service on fatal error
+	Output         io.Writer          // Output destination (default os.Stdout)
+	Encoder        Encoder            // Output format (default TextEncoder)
+	Sink           Sink               // Entry destination used instead of Output and Encoder
+	ShowCaller     bool               // Show caller file:line in logs
+	ShowFunction   bool               // Show caller function name in logs (with ShowCaller)
+	ShowStack      bool               // Attach stack traces to entries at StackLevel and above
+	StackLevel     *Level             // Minimal level of entries with stack traces (nil means LevelError)
+	StackDepth     int                // Maximal number of stack frames (default 32)
+	TraceExtractor TraceExtractor     // Extracts trace_id, span_id and trace_flags from context (default TraceFromContext)
+	level          Level              // Minimal log level set by SetLevel
+	title          string             // Process title
+	parent         *Logging           // Parent logger (nil for root loggers)
+	name           string             // Logger name
+	fields         []Field            // Fields bound to logger
+	callerSkip     int                // Additional stack frames to skip when capturing the caller, see AddCallerSkip
+	extractors     []ContextExtractor // Extractors of context fields, see AddContextExtractor
+	levelOverride  *Level             // Minimal log level of a derived logger, see WithLevel
+	uuidOverride   string             // Process UUID of a derived logger, see WithUUID
+	mu             sync.Mutex         // Guards writes to output
+	cfgMu          sync.RWMutex       // Guards configuration fields
 }
 
 // Get level of logging by level and context if it's present
 //
 // Parameters:
-//   - level - log level
+//   - level - log level (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info)
 //   - ctx - context
 func (logger *Logging) GetLevel(level int, ctx any) (string, string, bool) {
+	return logger.ResolveLevel(LevelFromInt(level), ctx)
+}
+
+// ResolveLevel returns the level label if the level is enabled (empty string otherwise),
+// the process UUID and the flag whether ctx is a context.
+//
+// Parameters:
+//   - level - log level
+//   - ctx - context
+func (logger *Logging) ResolveLevel(level Level, ctx any) (string, string, bool) {
+	cfg := logger.config()
+
+	return cfg.getLevel(level, ctx)
+}
+
+// getLevel returns level label, process UUID and context flag using the configuration snapshot
+//
+// Parameters:
+//   - level - log level
+//   - ctx - context
+func (cfg *config) getLevel(level Level, ctx any) (string, string, bool) {
 	var uuid string
 	withContext := false
 
-	switch ctx.(type) {
+	switch ctx := ctx.(type) {
 	case context.Context:
-		if ctx.(context.Context).Value(CtxKeyUUID) != nil {
-			uuid = ctx.(context.Context).Value(CtxKeyUUID).(string)
-		} else {
-			uuid = logger.UUID
+		var ok bool
+		if uuid, ok = UUIDFromContext(ctx); !ok {
+			uuid = cfg.uuid
 		}
 		withContext = true
 	default:
-		uuid = logger.UUID
+		uuid = cfg.uuid
 	}
 
-	levels := []string{"DBG", "WRN", "ERR", "FTL", "INF"}
-
-	if level < 0 || level > 4 {
-		level = 4
-	}
-
-	if level < logger.LogLevel {
+	if level < cfg.level {
 		return "", uuid, withContext
 	}
 
-	return levels[level], uuid, withContext
+	return level.Label(), uuid, withContext
 }
 
 // Print logs to console
@@ -66,35 +105,7 @@ func (logger *Logging) GetLevel(level int, ctx any) (string, string, bool) {
 //   - level - log level (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info)
 //   - args - arguments to print
 func (logger *Logging) Print(level int, args ...any) {
-	lev, uuid, withContext := logger.GetLevel(level, args[0])
-	if logger.ConsoleApp {
-		if level == 2 || level == 3 {
-			if withContext {
-				fmt.Print(fmt.Sprint(args[1:]...))
-			} else {
-				fmt.Print(fmt.Sprint(args...))
-			}
-		}
-		return // do not print logs in console app
-	}
-
-	if lev != "" {
-		t := time.Now()
-		if logger.ShowTime {
-			if withContext {
-				fmt.Printf("%s\t%v\t[%v]\t%v\n", logger.TimeToStr(t), lev, uuid, fmt.Sprint(args[1:]...))
-			} else {
-				fmt.Printf("%s\t%v\t[%v]\t%v\n", logger.TimeToStr(t), lev, uuid, fmt.Sprint(args...))
-			}
-		} else {
-			if withContext {
-				fmt.Printf("%v\t[%v]\t%v\n", lev, uuid, fmt.Sprint(args[1:]...))
-			} else {
-				fmt.Printf("%v\t[%v]\t%v\n", lev, uuid, fmt.Sprint(args...))
-			}
-		}
-
-	}
+	logger.log(1, LevelFromInt(level), printPlain, args)
 }
 
 // Printf logs formatted output to console
@@ -105,41 +116,280 @@ func (logger *Logging) Print(level int, args ...any) {
 //     # args[0] - format string
 //     # args[1:] - arguments to format string
 func (logger *Logging) Printf(level int, args ...any) {
-	lev, uuid, withContext := logger.GetLevel(level, args[0])
-	if logger.ConsoleApp {
-		if level == 2 || level == 3 {
-			if len(args) > 2 {
-				fmt.Printf("%v\n", fmt.Sprintf(args[1].(string), args[2:]...))
-			} else {
-				fmt.Printf("%v\n", fmt.Sprint(args[1:]...))
-			}
+	logger.log(1, LevelFromInt(level), printFormat, args)
+}
+
+// Printw logs a message with structured fields
+//
+// Parameters:
+//   - level - log level (0 - debug, 1 - warning, 2 - error, 3 - fatal, 4 - info)
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Printw(level int, args ...any) {
+	logger.log(1, LevelFromInt(level), printFields, args)
+}
+
+// Log logs to console
+//
+// Parameters:
+//   - level - log level
+//   - args - arguments to print
+//     # args[0] - context (optional) or argument to print
+//     # args[1:] - arguments to print
+func (logger *Logging) Log(level Level, args ...any) {
+	logger.log(1, level, printPlain, args)
+}
+
+// Logf logs formatted output to console
+//
+// Parameters:
+//   - level - log level
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (logger *Logging) Logf(level Level, args ...any) {
+	logger.log(1, level, printFormat, args)
+}
+
+// Logw logs a message with structured fields
+//
+// Parameters:
+//   - level - log level
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Logw(level Level, args ...any) {
+	logger.log(1, level, printFields, args)
+}
+
+// printMode defines how arguments of a log call are turned into a message
+type printMode int8
+
+const (
+	printPlain  printMode = iota // Arguments are printed with fmt.Sprint
+	printFormat                  // The first argument is a format string, see sprintf
+	printFields                  // The first argument is a message, the rest are fields
+)
+
+// log logs arguments at the level.
+// Every public logging method calls it directly, so the caller is found at a fixed stack depth.
+//
+// Parameters:
+//   - skip - number of stack frames between log and the code to report as the caller
+//     (1 for methods called by users)
+//   - level - log level
+//   - mode - how to build the message from arguments
+//   - args - an optional context followed by arguments to print
+func (logger *Logging) log(skip int, level Level, mode printMode, args []any) {
+	cfg := logger.config()
+
+	var pcs []uintptr
+	if depth := cfg.callersDepth(level); depth > 0 {
+		pcs = make([]uintptr, depth)
+		// skip runtime.Callers, log and the frames between log and the caller
+		pcs = pcs[:runtime.Callers(skip+2+logger.callerSkip, pcs)]
+	}
+
+	logger.logPC(&cfg, pcs, level, mode, args)
+}
+
+// logPC logs arguments at the level with the call stack given by program counters.
+//
+// Parameters:
+//   - cfg - configuration snapshot
+//   - pcs - program counters of the call stack starting with the caller
+//     (see config.callersDepth, nil if neither caller nor stack is reported)
+//   - level - log level
+//   - mode - how to build the message from arguments
+//   - args - an optional context followed by arguments to print
+func (logger *Logging) logPC(cfg *config, pcs []uintptr, level Level, mode printMode, args []any) {
+	var ctx any
+	if len(args) > 0 {
+		ctx = args[0]
+	}
+
+	lev, uuid, withContext := cfg.getLevel(level, ctx)
+	if withContext {
+		args = args[1:]
+	} else {
+		ctx = nil
+	}
+
+	if cfg.consoleApp {
+		if level < LevelError {
+			return // do not print logs in console app
+		}
+	} else if lev == "" {
+		return
+	}
+
+	var msg string
+	var fields []Field
+	var err error
+	switch mode {
+	case printPlain:
+		msg = fmt.Sprint(args...)
+		err = firstError(args)
+	case printFormat:
+		msg = sprintf(args)
+		err = firstError(args)
+	default:
+		if len(args) > 0 {
+			msg = fmt.Sprint(args[0])
+			fields = fieldsFromArgs(args[1:])
 		}
-		return // do not print logs in console app
 	}
 
-	if lev != "" {
-		t := time.Now()
-		if withContext {
-			if logger.ShowTime {
-				if len(args) > 2 {
-					fmt.Printf("%s\t%v\t[%v]\t%v\n", logger.TimeToStr(t), lev, uuid, fmt.Sprintf(args[1].(string), args[2:]...))
-				} else {
-					fmt.Printf("%s\t%v\t[%v]\t%v\n", logger.TimeToStr(t), lev, uuid, fmt.Sprint(args[1:]...))
-				}
-			} else {
-				if len(args) > 2 {
-					fmt.Printf("%v\t[%v]\t%v\n", lev, uuid, fmt.Sprintf(args[1].(string), args[2:]...))
-				} else {
-					fmt.Printf("%v\t[%v]\t%v\n", lev, uuid, fmt.Sprint(args[1:]...))
-				}
-			}
-		} else {
-			if logger.ShowTime {
-				fmt.Printf("%s\t%v\t[%v]\t%v\n", logger.TimeToStr(t), lev, uuid, fmt.Sprintf(args[0].(string), args[1:]...))
-			} else {
-				fmt.Printf("%v\t[%v]\t%v\n", lev, uuid, fmt.Sprintf(args[0].(string), args[1:]...))
-			}
+	if cfg.consoleApp {
+		if len(fields) > 0 {
+			msg += "\t" + string(appendTextFields(nil, fields))
+		}
+		if mode != printPlain {
+			msg += "\n"
 		}
+		logger.write(cfg, []byte(msg))
+		return
+	}
+
+	if ctx != nil {
+		fields = append(cfg.contextFields(ctx.(context.Context)), fields...)
+	}
+
+	entry := Entry{
+		Level:   level,
+		UUID:    uuid,
+		Message: msg,
+		Fields:  fields,
+		Error:   err,
+	}
+	if cfg.showCaller && len(pcs) > 0 {
+		entry.Caller = callerFromPC(pcs[0], cfg.showFunction)
+	}
+	if cfg.stackEnabled(level) {
+		entry.Stack = stackFromArgs(args, cfg.stackDepth)
+		if entry.Stack == "" {
+			entry.Stack = formatStack(pcs)
+		}
+	}
+
+	logger.output(cfg, &entry)
+}
+
+// sprintf formats arguments as a message.
+// If there are more than one argument the first one is used as a format string.
+//
+// Parameters:
+//   - args - arguments to format
+//     # args[0] - format string or argument to print
+//     # args[1:] - arguments to format string
+func sprintf(args []any) string {
+	if len(args) > 1 {
+		if format, ok := args[0].(string); ok {
+			return fmt.Sprintf(format, args[1:]...)
+		}
+	}
+
+	return fmt.Sprint(args...)
+}
+
+// output adds the time, service title, logger name and bound fields to a log entry,
+// then encodes and writes it to the output destination
+//
+// Parameters:
+//   - cfg - configuration snapshot
+//   - entry - entry with level, UUID, message, fields, caller and stack set
+func (logger *Logging) output(cfg *config, entry *Entry) {
+	if len(logger.fields) > 0 {
+		entry.Fields = append(logger.fields[:len(logger.fields):len(logger.fields)], entry.Fields...)
+	}
+
+	entry.Title = cfg.title
+	entry.Logger = logger.name
+	if cfg.showTime {
+		entry.Time = time.Now()
+	}
+
+	if cfg.sink != nil {
+		cfg.sink.WriteEntry(entry)
+		return
+	}
+
+	enc := cfg.encoder
+	if enc == nil {
+		enc = TextEncoder{}
+	}
+
+	logger.write(cfg, enc.Encode(nil, entry))
+}
+
+// write writes a string to the output destination.
+// Writes are serialized, so one logger can be used from several goroutines.
+//
+// Parameters:
+//   - cfg - configuration snapshot
+//   - p - bytes to write
+func (logger *Logging) write(cfg *config, p []byte) {
+	root := logger.root()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+
+	out := cfg.output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	out.Write(p)
+}
+
+// Flush writes entries buffered by the sink, e.g. by AsyncSink.
+// Fatal and Panic methods call it before the process exits or panics, waiting for it at most 5 seconds.
+func (logger *Logging) Flush() error {
+	cfg := logger.config()
+	if f, ok := cfg.sink.(Flusher); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// exitFlushTimeout is the maximal time exit waits for the sink to be flushed
+var exitFlushTimeout = 5 * time.Second
+
+// exit flushes the sink and exits the program with status code 1 unless DontStop is set.
+// A stuck sink doesn't prevent the exit, flushing is abandoned after exitFlushTimeout.
+func (logger *Logging) exit() {
+	if !logger.GetDontStop() {
+		logger.flushTimeout(exitFlushTimeout)
+		os.Exit(1) // Exit with status code 1
+	}
+}
+
+// flushTimeout flushes the sink, waiting at most timeout.
+//
+// Parameters:
+//   - timeout - maximal time to wait
+//
+// Returns:
+//   - bool: whether the sink was flushed in time
+func (logger *Logging) flushTimeout(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		logger.Flush()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
 	}
 }
 
@@ -152,6 +402,14 @@ func (logger *Logging) Printf(level int, args ...any) {
 // Returns:
 //   - string: representation of the time in the specified format
 func (logger *Logging) TimeToStr(t time.Time) string {
+	return timeToStr(t)
+}
+
+// timeToStr converts time.Time to string in format "2006/01/02 15:04:05.000"
+//
+// Parameters:
+//   - t - time.Time object to convert
+func timeToStr(t time.Time) string {
 	str := t.Format("2006/01/02 15:04:05.999")
 
 	if len(str) == 19 {
@@ -172,7 +430,7 @@ func (logger *Logging) TimeToStr(t time.Time) string {
 //     # args[0] - context (optional) or argument to print
 //     # args[1:] - arguments to print
 func (logger *Logging) Info(args ...any) {
-	logger.Printf(4, args...)
+	logger.log(1, LevelInfo, printFormat, args)
 }
 
 // Infof logs a formatted informational message.
@@ -183,7 +441,50 @@ func (logger *Logging) Info(args ...any) {
 //     # args[1] - format string (if args[0] is context) or argument to print
 //     # args[2:] - arguments to format string
 func (logger *Logging) Infof(args ...any) {
-	logger.Printf(4, args...)
+	logger.log(1, LevelInfo, printFormat, args)
+}
+
+// Infow logs an informational message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Infow(args ...any) {
+	logger.log(1, LevelInfo, printFields, args)
+}
+
+// Trace logs a trace message.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or argument to print
+//     # args[1:] - arguments to print
+func (logger *Logging) Trace(args ...any) {
+	logger.log(1, LevelTrace, printFormat, args)
+}
+
+// Tracef logs a formatted trace message.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (logger *Logging) Tracef(args ...any) {
+	logger.log(1, LevelTrace, printFormat, args)
+}
+
+// Tracew logs a trace message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Tracew(args ...any) {
+	logger.log(1, LevelTrace, printFields, args)
 }
 
 // Debug logs a debug message.
@@ -193,7 +494,7 @@ func (logger *Logging) Infof(args ...any) {
 //     # args[0] - context (optional) or argument to print
 //     # args[1:] - arguments to print
 func (logger *Logging) Debug(args ...any) {
-	logger.Printf(0, args...)
+	logger.log(1, LevelDebug, printFormat, args)
 }
 
 // Debugf logs a formatted debug message.
@@ -204,7 +505,18 @@ func (logger *Logging) Debug(args ...any) {
 //     # args[1] - format string (if args[0] is context)
 //     # args[2:] - arguments to format string
 func (logger *Logging) Debugf(args ...any) {
-	logger.Printf(0, args...)
+	logger.log(1, LevelDebug, printFormat, args)
+}
+
+// Debugw logs a debug message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Debugw(args ...any) {
+	logger.log(1, LevelDebug, printFields, args)
 }
 
 // Warn logs a warning message.
@@ -214,7 +526,7 @@ func (logger *Logging) Debugf(args ...any) {
 //     # args[0] - context (optional) or argument to print
 //     # args[1:] - arguments to print
 func (logger *Logging) Warn(args ...any) {
-	logger.Printf(1, args...)
+	logger.log(1, LevelWarn, printFormat, args)
 }
 
 // Warnf logs a formatted warning message.
@@ -225,7 +537,18 @@ func (logger *Logging) Warn(args ...any) {
 //     # args[1] - format string (if args[0] is context)
 //     # args[2:] - arguments to format string
 func (logger *Logging) Warnf(args ...any) {
-	logger.Printf(1, args...)
+	logger.log(1, LevelWarn, printFormat, args)
+}
+
+// Warnw logs a warning message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Warnw(args ...any) {
+	logger.log(1, LevelWarn, printFields, args)
 }
 
 // Error logs an error message.
@@ -235,7 +558,7 @@ func (logger *Logging) Warnf(args ...any) {
 //     # args[0] - context (optional) or argument to print
 //     # args[1:] - arguments to print
 func (logger *Logging) Error(args ...any) {
-	logger.Printf(2, args...)
+	logger.log(1, LevelError, printFormat, args)
 }
 
 // Errorf logs a formatted error message.
@@ -246,7 +569,18 @@ func (logger *Logging) Error(args ...any) {
 //     # args[1] - format string (if args[0] is context)
 //     # args[2:] - arguments to format string
 func (logger *Logging) Errorf(args ...any) {
-	logger.Printf(2, args...)
+	logger.log(1, LevelError, printFormat, args)
+}
+
+// Errorw logs an error message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Errorw(args ...any) {
+	logger.log(1, LevelError, printFields, args)
 }
 
 // Fatal logs a fatal error message and exits the program.
@@ -256,10 +590,8 @@ func (logger *Logging) Errorf(args ...any) {
 //     # args[0] - context (optional) or argument to print
 //     # args[1:] - arguments to print
 func (logger *Logging) Fatal(args ...any) {
-	logger.Printf(3, args...)
-	if !logger.DontStop {
-		os.Exit(1) // Exit with status code 1
-	}
+	logger.log(1, LevelFatal, printFormat, args)
+	logger.exit()
 }
 
 // Fatalf logs a formatted fatal error message and exits the program.
@@ -270,10 +602,67 @@ func (logger *Logging) Fatal(args ...any) {
 //     # args[1] - format string (if args[0] is context)
 //     # args[2:] - arguments to format string
 func (logger *Logging) Fatalf(args ...any) {
-	logger.Printf(3, args...)
-	if !logger.DontStop {
-		os.Exit(1) // Exit with status code 1
+	logger.log(1, LevelFatal, printFormat, args)
+	logger.exit()
+}
+
+// Fatalw logs a fatal error message with structured fields and exits the program.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Fatalw(args ...any) {
+	logger.log(1, LevelFatal, printFields, args)
+	logger.exit()
+}
+
+// Panic logs a message and panics with it.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or argument to print
+//     # args[1:] - arguments to print
+func (logger *Logging) Panic(args ...any) {
+	logger.log(1, LevelPanic, printFormat, args)
+	logger.flushTimeout(exitFlushTimeout)
+
+	_, args = splitContext(args)
+	panic(sprintf(args))
+}
+
+// Panicf logs a formatted message and panics with it.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (logger *Logging) Panicf(args ...any) {
+	logger.log(1, LevelPanic, printFormat, args)
+	logger.flushTimeout(exitFlushTimeout)
+
+	_, args = splitContext(args)
+	panic(sprintf(args))
+}
+
+// Panicw logs a message with structured fields and panics with the message.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (logger *Logging) Panicw(args ...any) {
+	logger.log(1, LevelPanic, printFields, args)
+	logger.flushTimeout(exitFlushTimeout)
+
+	_, args = splitContext(args)
+	if len(args) == 0 {
+		panic("")
 	}
+	panic(fmt.Sprint(args[0]))
 }
 
 // Starting service
@@ -281,13 +670,105 @@ func (logger *Logging) Fatalf(args ...any) {
 // Parameters:
 //   - title - process title
 func (logger *Logging) Starting(title string) {
-	logger.title = title
-	logger.Infof("%s service is starting...", title)
+	root := logger.root()
+	root.cfgMu.Lock()
+	root.title = title
+	root.cfgMu.Unlock()
+
+	logger.log(1, LevelInfo, printFormat, []any{"%s service is starting...", title})
 }
 
 // Stopping service
 func (logger *Logging) Stopping() {
-	logger.Infof("%s service is stopping...", logger.title)
+	cfg := logger.config()
+	logger.log(1, LevelInfo, printFormat, []any{"%s service is stopping...", cfg.title})
+}
+
+// With returns a derived logger which adds the given fields to every entry.
+// The derived logger shares configuration and output with its root logger,
+// so its own exported fields are not used.
+//
+// Parameters:
+//   - args - fields as Field values or alternating keys and values
+func (logger *Logging) With(args ...any) *Logging {
+	child := logger.derive()
+	child.fields = append(child.fields, fieldsFromArgs(args)...)
+
+	return child
+}
+
+// Named returns a derived logger with the name appended to the logger name.
+// Names of nested loggers are joined with dots, e.g. "billing.invoices".
+//
+// Parameters:
+//   - name - logger name
+func (logger *Logging) Named(name string) *Logging {
+	child := logger.derive()
+	switch {
+	case name == "":
+	case child.name == "":
+		child.name = name
+	default:
+		child.name += "." + name
+	}
+
+	return child
+}
+
+// AddCallerSkip returns a derived logger which skips n more stack frames when capturing the caller.
+// Use it in helper functions which wrap the logger, so the caller of the helper is reported.
+//
+// Parameters:
+//   - n - number of stack frames to skip
+func (logger *Logging) AddCallerSkip(n int) *Logging {
+	child := logger.derive()
+	child.callerSkip += n
+
+	return child
+}
+
+// WithLevel returns a derived logger with its own minimal log level,
+// e.g. to log one request at the debug level. Other configuration is shared with the root logger.
+//
+// Parameters:
+//   - level - minimal log level
+func (logger *Logging) WithLevel(level Level) *Logging {
+	child := logger.derive()
+	child.levelOverride = &level
+
+	return child
+}
+
+// WithUUID returns a derived logger which uses the UUID when the context has no UUID.
+//
+// Parameters:
+//   - uuid - process UUID
+func (logger *Logging) WithUUID(uuid string) *Logging {
+	child := logger.derive()
+	child.uuidOverride = uuid
+
+	return child
+}
+
+// derive creates a copy of the logger attached to the same root logger.
+func (logger *Logging) derive() *Logging {
+	return &Logging{
+		parent:        logger.root(),
+		name:          logger.name,
+		fields:        logger.fields[:len(logger.fields):len(logger.fields)],
+		callerSkip:    logger.callerSkip,
+		levelOverride: logger.levelOverride,
+		uuidOverride:  logger.uuidOverride,
+	}
+}
+
+// root returns the root logger which holds configuration and output.
+func (logger *Logging) root() *Logging {
+	if logger.parent != nil {
+		return logger.parent
+	}
+
+	return logger
 }
 
 // Initialize default parameters
@@ -1,7 +1,10 @@
 package logging
 
 import (
+	"bytes"
 	"context"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,13 +17,19 @@ func TestLogger_GetLevel(t *testing.T) {
 		level    int
 		want     string
 	}{
-		{2, -1, "INF"},
-		{2, 5, "INF"},
-		{2, 4, "INF"},
+		{2, -1, ""},
+		{2, 5, ""},
+		{2, 4, ""},
 		{2, 3, "FTL"},
 		{2, 2, "ERR"},
 		{2, 1, ""},
 		{2, 0, ""},
+		{4, 4, "INF"},
+		{4, 2, "ERR"},
+		{4, 0, ""},
+		{0, -1, "INF"},
+		{0, 5, "INF"},
+		{0, 4, "INF"},
 		{0, 1, "WRN"},
 		{0, 0, "DBG"},
 	}
@@ -33,6 +42,41 @@ func TestLogger_GetLevel(t *testing.T) {
 	}
 }
 
+func TestLogging_Output(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{
+		UUID:   "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Output: &buf,
+	}
+
+	ctx := context.WithValue(context.Background(), CtxKeyUUID, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	logger.Info("Hello World")
+	logger.Errorf(ctx, "Hello %s", "Universe")
+
+	want := "INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tHello World\n" +
+		"ERR\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tHello Universe\n"
+	require.Equal(t, want, buf.String())
+
+	buf.Reset()
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Warn("concurrent")
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 50)
+	for _, line := range lines {
+		require.Equal(t, "WRN\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tconcurrent", line)
+	}
+}
+
 func ExampleLogging_Print() {
 	Logs.LogLevel = 0
 	Logs.UUID = "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"
@@ -128,7 +172,6 @@ func ExampleLogging_Debug() {
 
 	// Unordered output:
 	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is starting...
-	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is stopping...
 	// DBG	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello World
 	// DBG	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello Universe
 	// DBG	[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]	Hello World
@@ -155,8 +198,6 @@ func ExampleLogging_Warn() {
 	}
 
 	// Unordered output:
-	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is starting...
-	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is stopping...
 	// WRN	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello World
 	// WRN	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello Universe
 	// WRN	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello World
@@ -187,8 +228,6 @@ func ExampleLogging_Error() {
 	}
 
 	// Unordered output:
-	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is starting...
-	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is stopping...
 	// ERR	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello World
 	// ERR	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello Universe
 	// ERR	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello World
@@ -224,8 +263,6 @@ func ExampleLogging_Fatal() {
 	}
 
 	// Unordered output:
-	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is starting...
-	// INF	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	test service is stopping...
 	// FTL	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello World
 	// FTL	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello Universe
 	// FTL	[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]	Hello World
@@ -243,3 +280,73 @@ func ExampleLogging_Fatal() {
 	// FTL	[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]	Hello World
 	// FTL	[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]	Hello Universe
 }
+
+func TestLogging_With(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{
+		UUID:   "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Output: &buf,
+	}
+
+	billing := logger.Named("billing").With("component", "billing")
+	invoices := billing.Named("invoices").With(Int("shard", 3))
+
+	billing.Info("charged")
+	invoices.Warnw("overdue", "invoice_id", 17)
+	logger.Info("root")
+
+	want := "INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tbilling: charged\tcomponent=billing\n" +
+		"WRN\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tbilling.invoices: overdue\tcomponent=billing shard=3 invoice_id=17\n" +
+		"INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\troot\n"
+	require.Equal(t, want, buf.String())
+
+	// Derived loggers follow configuration of the root logger
+	buf.Reset()
+	logger.LogLevel = 2
+	logger.Encoder = JSONEncoder{}
+	invoices.Warn("filtered")
+	invoices.Error("failed")
+
+	require.Equal(t, `{"level":"error","uuid":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","logger":"billing.invoices","msg":"failed","component":"billing","shard":3}`+"\n", buf.String())
+}
+
+func TestLogging_Setters(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"}
+	logger.SetOutput(&buf)
+	child := logger.With("component", "billing")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			logger.SetLogLevel(i % 3)
+			logger.SetShowTime(i%2 == 0)
+			logger.SetConsoleApp(false)
+			logger.SetDontStop(true)
+			logger.SetEncoder(TextEncoder{})
+		}()
+		go func() {
+			defer wg.Done()
+			child.Error("concurrent")
+			logger.Infof("Hello %s", "Universe")
+		}()
+	}
+	wg.Wait()
+
+	logger.SetLogLevel(2)
+	logger.SetUUID("4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	logger.SetShowTime(false)
+
+	require.Equal(t, 2, child.GetLogLevel())
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f", child.GetUUID())
+	require.False(t, logger.GetShowTime())
+	require.False(t, logger.GetConsoleApp())
+	require.True(t, logger.GetDontStop())
+
+	buf.Reset()
+	child.Warn("filtered")
+	child.Error("shown")
+	require.Equal(t, "ERR\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tshown\tcomponent=billing\n", buf.String())
+}
@@ -0,0 +1,216 @@
+package logging
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRequestIDHeader is the default header with the request ID
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximal length of an incoming request ID
+const maxRequestIDLength = 128
+
+// MiddlewareOptions configures the middleware created by NewMiddleware.
+type MiddlewareOptions struct {
+	Logger        *Logging                                // Logger for the access log (default Logs)
+	Header        string                                  // Request ID header (default "X-Request-ID")
+	Level         func(r *http.Request, status int) Level // Level of access log entries (default AccessLevel)
+	SkipAccessLog bool                                    // Do not log requests
+}
+
+// NewMiddleware creates net/http middleware which assigns a process UUID to every request.
+//
+// The ID is taken from the request ID header or a new random UUID is generated
+// if the header is missing or malformed. The ID is stored in the request context by ContextWithUUID
+// and by CtxKeyUUID, and is echoed in the response header. After the request is served,
+// an access log entry with method, path, status, bytes and latency fields is written.
+//
+// Parameters:
+//   - opts - options
+//
+// Returns:
+//   - func(http.Handler) http.Handler: function wrapping a handler with the middleware
+func NewMiddleware(opts MiddlewareOptions) func(http.Handler) http.Handler {
+	if opts.Logger == nil {
+		opts.Logger = &Logs
+	}
+	if opts.Header == "" {
+		opts.Header = DefaultRequestIDHeader
+	}
+	if opts.Level == nil {
+		opts.Level = AccessLevel
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(opts.Header)
+			if !ValidRequestID(id) {
+				id = uuid.New().String()
+			}
+
+			// CtxKeyUUID is set too for code which reads it directly
+			ctx := context.WithValue(ContextWithUUID(r.Context(), id), CtxKeyUUID, id)
+			r = r.WithContext(ctx)
+			w.Header().Set(opts.Header, id)
+
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+
+			if opts.SkipAccessLog {
+				return
+			}
+
+			status := sw.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			opts.Logger.Logw(opts.Level(r, status), ctx, "http request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", status,
+				"bytes", sw.bytes,
+				Duration("latency", time.Since(start)),
+			)
+		})
+	}
+}
+
+// AccessLevel returns the level of an access log entry by the response status:
+// LevelError for 5xx, LevelWarn for 4xx and LevelInfo for other statuses.
+//
+// Parameters:
+//   - r - request
+//   - status - response status
+func AccessLevel(r *http.Request, status int) Level {
+	switch {
+	case status >= 500:
+		return LevelError
+	case status >= 400:
+		return LevelWarn
+	default:
+		return LevelInfo
+	}
+}
+
+// ValidRequestID reports whether a request ID received from another service can be used as the process UUID:
+// it must be non-empty, at most 128 bytes long and consist of printable ASCII characters.
+// It is used by NewMiddleware and can be used by other transports.
+//
+// Parameters:
+//   - id - request ID
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] >= 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
+
+// statusWriter records the status and the number of bytes written to a response
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// WriteHeader records the status and sends the response header.
+//
+// Parameters:
+//   - status - response status
+func (w *statusWriter) WriteHeader(status int) {
+	// informational responses are followed by the final one
+	if w.status == 0 && status >= 200 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of written bytes and writes the response body.
+//
+// Parameters:
+//   - p - bytes to write
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+
+	return n, err
+}
+
+// Flush sends buffered data to the client if the wrapped writer supports it.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack takes over the connection if the wrapped writer supports it, e.g. for WebSocket handlers.
+// The request is logged with status 101.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("logging: %T doesn't support hijacking: %w", w.ResponseWriter, http.ErrNotSupported)
+	}
+
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+
+	return conn, rw, err
+}
+
+// ReadFrom copies the response body from r using the wrapped writer's io.ReaderFrom if it has one,
+// e.g. to send files with sendfile.
+//
+// Parameters:
+//   - r - source of the response body
+func (w *statusWriter) ReadFrom(r io.Reader) (int64, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	var n int64
+	var err error
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(r)
+	} else {
+		// writerOnly hides ReadFrom of statusWriter, so io.Copy doesn't call it again
+		n, err = io.Copy(writerOnly{w.ResponseWriter}, r)
+	}
+	w.bytes += n
+
+	return n, err
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writerOnly hides all methods of a writer except Write
+type writerOnly struct {
+	io.Writer
+}
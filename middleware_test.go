@@ -0,0 +1,166 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Output: &buf}
+
+	var gotID, gotOldKey string
+	handler := NewMiddleware(MiddlewareOptions{Logger: logger})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID, _ = UUIDFromContext(r.Context())
+		gotOldKey, _ = r.Context().Value(CtxKeyUUID).(string)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, "hello")
+	}))
+
+	// The incoming request ID is used
+	req := httptest.NewRequest(http.MethodGet, "/orders?id=1", nil)
+	req.Header.Set("X-Request-ID", "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f", rec.Header().Get("X-Request-ID"))
+	require.Equal(t, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f", gotID)
+	require.Equal(t, gotID, gotOldKey)
+	require.Regexp(t, regexp.MustCompile(`^INF\t\[4577c272-e9b8-4a19-a9d0-4ec0bde6063f\]\thttp request\tmethod=GET path=/orders status=200 bytes=5 latency=\S+\n$`), buf.String())
+
+	// A new UUID is generated if the header is missing or malformed
+	for _, header := range []string{"", "bad id", strings.Repeat("x", 200)} {
+		buf.Reset()
+		req = httptest.NewRequest(http.MethodPost, "/missing", nil)
+		if header != "" {
+			req.Header.Set("X-Request-ID", header)
+		}
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.NoError(t, uuid.Validate(gotID))
+		require.Equal(t, gotID, rec.Header().Get("X-Request-ID"))
+		require.True(t, strings.HasPrefix(buf.String(), "WRN\t["+gotID+"]\thttp request\tmethod=POST path=/missing status=404 bytes=19 "), buf.String())
+	}
+}
+
+func TestMiddleware_Options(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "id", Output: &buf}
+
+	handler := NewMiddleware(MiddlewareOptions{
+		Logger: logger,
+		Header: "X-Correlation-ID",
+		Level: func(r *http.Request, status int) Level {
+			return LevelDebug
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.Info(r.Context(), "handling")
+		w.WriteHeader(http.StatusAccepted)
+		http.NewResponseController(w).Flush()
+	}))
+
+	req := httptest.NewRequest(http.MethodPut, "/jobs", nil)
+	req.Header.Set("X-Correlation-ID", "job-1")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, "job-1", rec.Header().Get("X-Correlation-ID"))
+	require.True(t, rec.Flushed)
+	require.True(t, strings.HasPrefix(buf.String(), "INF\t[job-1]\thandling\nDBG\t[job-1]\thttp request\tmethod=PUT path=/jobs status=202 bytes=0 "), buf.String())
+
+	// The access log can be disabled
+	buf.Reset()
+	handler = NewMiddleware(MiddlewareOptions{Logger: logger, SkipAccessLog: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.Background()))
+	require.Empty(t, buf.String())
+}
+
+func TestMiddleware_Hijack(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "id", Output: &buf}
+
+	handler := NewMiddleware(MiddlewareOptions{Logger: logger})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		rw.Flush()
+	}))
+
+	// The access log is written after the handler returns
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		handler.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/ws")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, "hijacked", string(body))
+
+	<-done
+	require.Contains(t, buf.String(), "]\thttp request\tmethod=GET path=/ws status=101 bytes=0 ")
+
+	// Writers without hijacking support return an error
+	buf.Reset()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Contains(t, rec.Body.String(), "doesn't support hijacking")
+}
+
+func TestMiddleware_ReadFrom(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "id", Output: &buf}
+
+	handler := NewMiddleware(MiddlewareOptions{Logger: logger})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, ok := w.(io.ReaderFrom)
+		require.True(t, ok)
+		io.Copy(w, strings.NewReader("hello"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/file", nil))
+	require.Equal(t, "hello", rec.Body.String())
+	require.Contains(t, buf.String(), "]\thttp request\tmethod=GET path=/file status=200 bytes=5 ")
+}
+
+func TestAccessLevel(t *testing.T) {
+	require.Equal(t, LevelInfo, AccessLevel(nil, http.StatusOK))
+	require.Equal(t, LevelInfo, AccessLevel(nil, http.StatusFound))
+	require.Equal(t, LevelWarn, AccessLevel(nil, http.StatusBadRequest))
+	require.Equal(t, LevelError, AccessLevel(nil, http.StatusBadGateway))
+}
+
+func ExampleNewMiddleware() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orders", func(w http.ResponseWriter, r *http.Request) {
+		Logs.Info(r.Context(), "listing orders")
+	})
+
+	handler := NewMiddleware(MiddlewareOptions{})(mux)
+	_ = handler // http.ListenAndServe(":8080", handler)
+}
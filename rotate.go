@@ -0,0 +1,383 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rotation is a schedule of time-based file rotation.
+type Rotation int
+
+const (
+	RotateNever  Rotation = iota // Rotate by size only
+	RotateHourly                 // Rotate at the beginning of every hour
+	RotateDaily                  // Rotate at midnight
+)
+
+// backupTimeFormat is a timestamp layout used in names of rotated files
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFile is an io.WriteCloser which writes to a file and rotates it
+// when it reaches the maximum size or by a schedule. It can be used as Logging.Output.
+//
+// Rotated files are renamed to "name-TIMESTAMP.ext" in the same directory.
+// Writes and rotation are serialized, so no lines are lost or split during rotation.
+type RotatingFile struct {
+	Filename   string   // File path
+	MaxSize    int64    // Maximum file size in bytes before rotation (0 - unlimited)
+	Rotation   Rotation // Time-based rotation schedule
+	MaxBackups int      // Maximum number of rotated files to keep (0 - keep all)
+	MaxAge     int      // Maximum number of days to keep rotated files (0 - keep all)
+	Compress   bool     // Compress rotated files with gzip
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	next   time.Time      // Time of the next scheduled rotation
+	mill   sync.Mutex     // Serializes compression and removal of rotated files
+	millWg sync.WaitGroup // Running compression and removal
+	sighup chan os.Signal
+	closed bool // Close was called, Reopen doesn't open the file again
+	now    func() time.Time
+	rename func(oldpath, newpath string) error
+}
+
+// Write writes p to the file, rotating it first if needed.
+//
+// Parameters:
+//   - p - bytes to write
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+		f.closed = false
+	}
+
+	due := !f.next.IsZero() && !f.clock().Before(f.next)
+	if due || (f.MaxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.MaxSize) {
+		// if rotation fails, p is written to the current file, so no line is lost
+		if err := f.rotate(); err != nil && due {
+			f.next = f.nextRotation(f.clock())
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Rotate renames the current file and opens a new one.
+// If rotation fails, writes go to the current file.
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rotate()
+}
+
+// Reopen closes and reopens the file. It is used after the file was moved by an external tool like logrotate.
+// It does nothing after Close until the next Write.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil
+	}
+
+	if err := f.close(); err != nil {
+		return err
+	}
+
+	return f.open()
+}
+
+// Close stops SIGHUP handling, closes the file and waits for compression and removal of rotated files.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	f.stopSIGHUP()
+	err := f.close()
+	f.closed = true
+	f.mu.Unlock()
+
+	f.millWg.Wait()
+
+	return err
+}
+
+// open opens or creates the file and schedules the next rotation.
+func (f *RotatingFile) open() error {
+	file, size, err := f.openFile()
+	if err != nil {
+		return err
+	}
+
+	f.file = file
+	f.size = size
+	f.next = f.nextRotation(f.clock())
+
+	return nil
+}
+
+// openFile opens or creates the file for appending.
+//
+// Returns:
+//   - *os.File: opened file
+//   - int64: current file size
+//   - error: error if the file can't be opened
+func (f *RotatingFile) openFile() (*os.File, int64, error) {
+	if err := os.MkdirAll(filepath.Dir(f.Filename), 0o755); err != nil {
+		return nil, 0, err
+	}
+
+	file, err := os.OpenFile(f.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	return file, info.Size(), nil
+}
+
+// close closes the file if it's open.
+func (f *RotatingFile) close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+// rotate renames the current file to a backup name, opens a new file
+// and starts compression and removal of old backups.
+// Files are swapped only after the rename succeeds, so the current file stays open if rotation fails.
+func (f *RotatingFile) rotate() error {
+	rename := f.rename
+	if rename == nil {
+		rename = os.Rename
+	}
+
+	var backup string
+	if _, err := os.Stat(f.Filename); err == nil {
+		backup = f.backupName(f.clock())
+		if err := rename(f.Filename, backup); err != nil {
+			return err
+		}
+	}
+
+	file, size, err := f.openFile()
+	if err != nil {
+		if backup != "" {
+			// the current file is still open, give it its name back
+			rename(backup, f.Filename)
+		}
+		return err
+	}
+
+	err = f.close()
+	f.file = file
+	f.size = size
+	f.next = f.nextRotation(f.clock())
+
+	f.millWg.Add(1)
+	go func() {
+		defer f.millWg.Done()
+		f.millRun()
+	}()
+
+	return err
+}
+
+// backupName returns an unused name for a rotated file.
+//
+// Parameters:
+//   - t - rotation time
+func (f *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := f.nameParts()
+	base := filepath.Join(dir, prefix+t.Format(backupTimeFormat))
+
+	name := base + ext
+	for i := 1; ; i++ {
+		_, err := os.Stat(name)
+		_, errGz := os.Stat(name + ".gz")
+		if os.IsNotExist(err) && os.IsNotExist(errGz) {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d%s", base, i, ext)
+	}
+}
+
+// nameParts returns directory, backup name prefix and extension of the file.
+func (f *RotatingFile) nameParts() (string, string, string) {
+	dir := filepath.Dir(f.Filename)
+	base := filepath.Base(f.Filename)
+	ext := filepath.Ext(base)
+
+	return dir, strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// nextRotation returns the time of the next scheduled rotation (zero if not scheduled).
+//
+// Parameters:
+//   - t - current time
+func (f *RotatingFile) nextRotation(t time.Time) time.Time {
+	switch f.Rotation {
+	case RotateHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+	case RotateDaily:
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	}
+
+	return time.Time{}
+}
+
+// clock returns the current time.
+func (f *RotatingFile) clock() time.Time {
+	if f.now != nil {
+		return f.now()
+	}
+
+	return time.Now()
+}
+
+// backupFile is a rotated file
+type backupFile struct {
+	path string
+	time time.Time
+}
+
+// millRun compresses rotated files and removes the ones exceeding MaxBackups or MaxAge.
+func (f *RotatingFile) millRun() {
+	f.mill.Lock()
+	defer f.mill.Unlock()
+
+	backups := f.backups()
+
+	var remove []backupFile
+	if f.MaxBackups > 0 && len(backups) > f.MaxBackups {
+		remove = append(remove, backups[f.MaxBackups:]...)
+		backups = backups[:f.MaxBackups]
+	}
+	if f.MaxAge > 0 {
+		cutoff := f.clock().Add(-time.Duration(f.MaxAge) * 24 * time.Hour)
+		kept := backups[:0]
+		for _, b := range backups {
+			if b.time.Before(cutoff) {
+				remove = append(remove, b)
+			} else {
+				kept = append(kept, b)
+			}
+		}
+		backups = kept
+	}
+
+	for _, b := range remove {
+		os.Remove(b.path)
+	}
+
+	if f.Compress {
+		for _, b := range backups {
+			if !strings.HasSuffix(b.path, ".gz") {
+				compressFile(b.path)
+			}
+		}
+	}
+}
+
+// backups returns rotated files sorted from the newest to the oldest.
+func (f *RotatingFile) backups() []backupFile {
+	dir, prefix, ext := f.nameParts()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var backups []backupFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		stamp := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ext)
+		stamp = strings.TrimPrefix(stamp, prefix)
+		if len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+
+		t, err := time.ParseInLocation(backupTimeFormat, stamp[:len(backupTimeFormat)], time.Local)
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, backupFile{path: filepath.Join(dir, name), time: t})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].time.Equal(backups[j].time) {
+			return backups[i].path > backups[j].path
+		}
+		return backups[i].time.After(backups[j].time)
+	})
+
+	return backups
+}
+
+// compressFile compresses a file with gzip and removes the original file.
+//
+// Parameters:
+//   - path - file path
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		gz.Close()
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		dst.Close()
+		os.Remove(path + ".gz")
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	src.Close()
+
+	return os.Remove(path)
+}
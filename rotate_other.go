@@ -0,0 +1,9 @@
+//go:build !unix
+
+package logging
+
+// HandleSIGHUP does nothing, there is no SIGHUP on this platform.
+func (f *RotatingFile) HandleSIGHUP() {}
+
+// stopSIGHUP does nothing, SIGHUP is never handled on this platform.
+func (f *RotatingFile) stopSIGHUP() {}
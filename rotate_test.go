@@ -0,0 +1,199 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// listDir returns sorted names of files in a directory
+func listDir(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func TestRotatingFile_Size(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 17, 18, 0, 0, 0, time.Local)
+
+	f := &RotatingFile{
+		Filename:   filepath.Join(dir, "app.log"),
+		MaxSize:    20,
+		MaxBackups: 2,
+		now:        func() time.Time { return now },
+	}
+
+	for _, line := range []string{"line 1 0123456789\n", "line 2 0123456789\n", "line 3 0123456789\n", "line 4 0123456789\n"} {
+		now = now.Add(time.Second)
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Close())
+
+	require.Equal(t, []string{
+		"app-20250617T180003.000.log",
+		"app-20250617T180004.000.log",
+		"app.log",
+	}, listDir(t, dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	require.Equal(t, "line 4 0123456789\n", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "app-20250617T180004.000.log"))
+	require.NoError(t, err)
+	require.Equal(t, "line 3 0123456789\n", string(data))
+}
+
+func TestRotatingFile_RenameError(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 17, 18, 0, 0, 0, time.Local)
+
+	renameErr := errors.New("rename failed")
+	f := &RotatingFile{
+		Filename: filepath.Join(dir, "app.log"),
+		MaxSize:  20,
+		now:      func() time.Time { return now },
+		rename:   func(oldpath, newpath string) error { return renameErr },
+	}
+
+	// Lines are written to the current file while rotation fails
+	for _, line := range []string{"line 1 0123456789\n", "line 2 0123456789\n"} {
+		now = now.Add(time.Second)
+		n, err := f.Write([]byte(line))
+		require.NoError(t, err)
+		require.Equal(t, len(line), n)
+	}
+	require.ErrorIs(t, f.Rotate(), renameErr)
+
+	// Rotation succeeds when the rename works again
+	f.rename = nil
+	now = now.Add(time.Second)
+	_, err := f.Write([]byte("line 3 0123456789\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.Equal(t, []string{"app-20250617T180003.000.log", "app.log"}, listDir(t, dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, "app-20250617T180003.000.log"))
+	require.NoError(t, err)
+	require.Equal(t, "line 1 0123456789\nline 2 0123456789\n", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, "app.log"))
+	require.NoError(t, err)
+	require.Equal(t, "line 3 0123456789\n", string(data))
+}
+
+func TestRotatingFile_Schedule(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 17, 18, 59, 0, 0, time.Local)
+
+	f := &RotatingFile{
+		Filename: filepath.Join(dir, "app.log"),
+		Rotation: RotateHourly,
+		Compress: true,
+		MaxAge:   1,
+		now:      func() time.Time { return now },
+	}
+
+	// A backup older than MaxAge is removed
+	old := filepath.Join(dir, "app-20250610T000000.000.log.gz")
+	require.NoError(t, os.WriteFile(old, nil, 0o644))
+
+	_, err := f.Write([]byte("before\n"))
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	_, err = f.Write([]byte("after\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.Equal(t, []string{"app-20250617T190000.000.log.gz", "app.log"}, listDir(t, dir))
+
+	gz, err := os.Open(filepath.Join(dir, "app-20250617T190000.000.log.gz"))
+	require.NoError(t, err)
+	defer gz.Close()
+
+	r, err := gzip.NewReader(gz)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "before\n", string(data))
+
+	require.Equal(t, time.Date(2025, 6, 18, 0, 0, 0, 0, time.Local), (&RotatingFile{Rotation: RotateDaily}).nextRotation(now))
+}
+
+func TestRotatingFile_ReopenAfterClose(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+
+	f := &RotatingFile{Filename: name}
+	_, err := f.Write([]byte("first\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// A reopen racing with Close doesn't open the file again
+	require.NoError(t, os.Remove(name))
+	require.NoError(t, f.Reopen())
+	require.Nil(t, f.file)
+	require.NoFileExists(t, name)
+
+	// Write opens the closed file, Reopen works again
+	_, err = f.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, os.Rename(name, name+".1"))
+	require.NoError(t, f.Reopen())
+	require.FileExists(t, name)
+	require.NoError(t, f.Close())
+}
+
+func TestRotatingFile_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	f := &RotatingFile{
+		Filename: filepath.Join(dir, "app.log"),
+		MaxSize:  1024,
+	}
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Output: f}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				logger.Info("concurrent")
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, f.Close())
+
+	lines := 0
+	for _, name := range listDir(t, dir) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			require.Equal(t, "INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tconcurrent", line)
+			lines++
+		}
+	}
+	require.Equal(t, 500, lines)
+}
@@ -0,0 +1,39 @@
+//go:build unix
+
+package logging
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// HandleSIGHUP reopens the file every time the process receives SIGHUP.
+// Handling is stopped by Close.
+func (f *RotatingFile) HandleSIGHUP() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.sighup != nil {
+		return
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	f.sighup = ch
+
+	go func() {
+		for range ch {
+			f.Reopen()
+		}
+	}()
+}
+
+// stopSIGHUP stops SIGHUP handling, f.mu must be held.
+func (f *RotatingFile) stopSIGHUP() {
+	if f.sighup != nil {
+		signal.Stop(f.sighup)
+		close(f.sighup)
+		f.sighup = nil
+	}
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+
+	f := &RotatingFile{Filename: name}
+	f.HandleSIGHUP()
+	defer f.Close()
+
+	_, err := f.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	// External logrotate moves the file and sends SIGHUP
+	require.NoError(t, os.Rename(name, name+".1"))
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(name)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = f.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, "second\n", string(data))
+}
@@ -0,0 +1,213 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Sink receives log entries instead of Output. Sinks are used for destinations
+// which need the entry fields, not only the encoded line (e.g. syslog or journald).
+// Sinks must not modify the entry or keep it after WriteEntry returns.
+type Sink interface {
+	// WriteEntry writes a log entry.
+	WriteEntry(e *Entry) error
+}
+
+// Flusher is implemented by sinks which buffer entries.
+type Flusher interface {
+	// Flush writes all buffered entries.
+	Flush() error
+}
+
+// WriterSink is a Sink which encodes entries and writes them to an io.Writer.
+type WriterSink struct {
+	Writer  io.Writer // Output destination (default os.Stdout)
+	Encoder Encoder   // Output format (default TextEncoder)
+
+	mu sync.Mutex
+}
+
+// WriteEntry encodes an entry and writes it.
+//
+// Parameters:
+//   - e - entry to write
+func (w *WriterSink) WriteEntry(e *Entry) error {
+	enc := w.Encoder
+	if enc == nil {
+		enc = TextEncoder{}
+	}
+	b := enc.Encode(nil, e)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	out := w.Writer
+	if out == nil {
+		out = os.Stdout
+	}
+
+	_, err := out.Write(b)
+
+	return err
+}
+
+// TeeTarget is a sink of TeeSink with its own minimal level and error handling.
+type TeeTarget struct {
+	Sink      Sink        // Destination
+	Level     Level       // Minimal level of entries written to the sink
+	OnError   func(error) // Called when the sink fails (optional)
+	Sync      bool        // Write on the caller's goroutine instead of the target's own queue
+	QueueSize int         // Capacity of the target queue (default 1024)
+}
+
+// TeeSink is a Sink which writes every entry to several sinks.
+// A failing, panicking or hanging sink doesn't prevent writing to the others:
+// every target has its own queue drained by its own goroutine (see AsyncSink),
+// new entries are dropped when the queue of a stuck target is full.
+// Targets with Sync set are written on the caller's goroutine.
+type TeeSink struct {
+	targets []teeTarget
+}
+
+// teeTarget is a target with its queue (nil for synchronous targets)
+type teeTarget struct {
+	TeeTarget
+	async *AsyncSink
+}
+
+// Tee creates a sink which writes entries to all targets with a suitable level.
+//
+// Parameters:
+//   - targets - sinks with their levels
+func Tee(targets ...TeeTarget) *TeeSink {
+	t := &TeeSink{targets: make([]teeTarget, len(targets))}
+	for i, target := range targets {
+		t.targets[i].TeeTarget = target
+		if !target.Sync {
+			t.targets[i].async = NewAsyncSink(&safeSink{sink: target.Sink}, AsyncOptions{
+				QueueSize: target.QueueSize,
+				Overflow:  OverflowDropNewest,
+				OnError:   target.OnError,
+			})
+		}
+	}
+
+	return t
+}
+
+// WriteEntry writes an entry to every target whose level allows it.
+// Entries are queued for asynchronous targets, errors of synchronous targets are joined.
+//
+// Parameters:
+//   - e - entry to write
+func (t *TeeSink) WriteEntry(e *Entry) error {
+	var errs []error
+	for _, target := range t.targets {
+		if e.Level < target.Level {
+			continue
+		}
+
+		if target.async != nil {
+			errs = append(errs, target.async.WriteEntry(e))
+			continue
+		}
+
+		if err := writeSafe(target.Sink, e); err != nil {
+			if target.OnError != nil {
+				target.OnError(err)
+			}
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Dropped returns the number of entries dropped because queues of targets were full.
+func (t *TeeSink) Dropped() uint64 {
+	var n uint64
+	for _, target := range t.targets {
+		if target.async != nil {
+			n += target.async.Dropped()
+		}
+	}
+
+	return n
+}
+
+// Flush waits until queued entries are written and flushes all targets which implement Flusher.
+func (t *TeeSink) Flush() error {
+	var errs []error
+	for _, target := range t.targets {
+		if target.async != nil {
+			errs = append(errs, target.async.Flush())
+		} else if f, ok := target.Sink.(Flusher); ok {
+			errs = append(errs, f.Flush())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close writes queued entries and closes all targets which implement io.Closer.
+func (t *TeeSink) Close() error {
+	var errs []error
+	for _, target := range t.targets {
+		if target.async != nil {
+			errs = append(errs, target.async.Close())
+		} else if c, ok := target.Sink.(io.Closer); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// safeSink is a sink which converts panics of the wrapped sink to errors
+type safeSink struct {
+	sink Sink
+}
+
+// WriteEntry writes an entry to the wrapped sink converting a panic to an error.
+//
+// Parameters:
+//   - e - entry to write
+func (s *safeSink) WriteEntry(e *Entry) error {
+	return writeSafe(s.sink, e)
+}
+
+// Flush flushes the wrapped sink if it implements Flusher.
+func (s *safeSink) Flush() error {
+	if f, ok := s.sink.(Flusher); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// Close closes the wrapped sink if it implements io.Closer.
+func (s *safeSink) Close() error {
+	if c, ok := s.sink.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// writeSafe writes an entry to a sink converting a panic to an error.
+//
+// Parameters:
+//   - sink - sink to write to
+//   - e - entry to write
+func writeSafe(sink Sink, e *Entry) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("logging: sink panicked: %v", r)
+		}
+	}()
+
+	return sink.WriteEntry(e)
+}
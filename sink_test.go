@@ -0,0 +1,102 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// failingSink always fails
+type failingSink struct {
+	panics bool
+}
+
+func (s failingSink) WriteEntry(e *Entry) error {
+	if s.panics {
+		panic("broken sink")
+	}
+
+	return errors.New("sink is down")
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := &WriterSink{Writer: &buf, Encoder: LogfmtEncoder{}}
+
+	require.NoError(t, sink.WriteEntry(&Entry{Level: LevelWarn, UUID: "id", Message: "Hello"}))
+	require.Equal(t, "level=warn uuid=id msg=Hello\n", buf.String())
+}
+
+func TestTeeSink(t *testing.T) {
+	var text, json, errs bytes.Buffer
+	var mu sync.Mutex
+	var failures []error
+
+	tee := Tee(
+		TeeTarget{Sink: &WriterSink{Writer: &text}, Level: LevelInfo},
+		TeeTarget{Sink: failingSink{}, Level: LevelDebug, OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, err)
+		}},
+		TeeTarget{Sink: failingSink{panics: true}, Level: LevelError, Sync: true},
+		TeeTarget{Sink: &WriterSink{Writer: &json, Encoder: JSONEncoder{}}, Level: LevelDebug},
+		TeeTarget{Sink: &WriterSink{Writer: &errs}, Level: LevelError, Sync: true},
+	)
+
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Sink: tee}
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Error("error")
+
+	// Entries of synchronous targets are written immediately
+	require.Equal(t, "ERR\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\terror\n", errs.String())
+
+	require.NoError(t, tee.Flush())
+	require.Equal(t, "INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tinfo\n"+
+		"ERR\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\terror\n", text.String())
+	require.Equal(t, `{"level":"debug","uuid":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","msg":"debug"}`+"\n"+
+		`{"level":"info","uuid":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","msg":"info"}`+"\n"+
+		`{"level":"error","uuid":"b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049","msg":"error"}`+"\n", json.String())
+	mu.Lock()
+	require.Len(t, failures, 3)
+	mu.Unlock()
+
+	// Errors of synchronous targets are returned
+	err := tee.WriteEntry(&Entry{Level: LevelFatal, Message: "fatal"})
+	require.ErrorContains(t, err, "sink panicked: broken sink")
+
+	require.NoError(t, tee.Flush())
+	require.NoError(t, tee.Close())
+}
+
+func TestTeeSink_Stuck(t *testing.T) {
+	var buf bytes.Buffer
+	stuck := newGatedSink()
+
+	tee := Tee(
+		TeeTarget{Sink: stuck, QueueSize: 1},
+		TeeTarget{Sink: &WriterSink{Writer: &buf}},
+	)
+
+	// A hanging target doesn't block the others
+	logger := &Logging{UUID: "id", Sink: tee}
+	for range 5 {
+		logger.Info("hello")
+	}
+	<-stuck.started
+
+	require.Eventually(t, func() bool {
+		return tee.Dropped() >= 3
+	}, time.Second, 10*time.Millisecond)
+
+	close(stuck.gate)
+	require.NoError(t, tee.Close())
+	require.Equal(t, 5, strings.Count(buf.String(), "INF\t[id]\thello\n"))
+	require.True(t, stuck.closed)
+}
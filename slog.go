@@ -0,0 +1,395 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
+)
+
+// SlogHandler is a slog.Handler which renders records through Logging.
+// Group names are added to attribute keys as dot-separated prefixes.
+type SlogHandler struct {
+	logger *Logging
+	fields []any  // Attributes added by WithAttrs
+	prefix string // Key prefix built by WithGroup
+}
+
+// NewSlogHandler creates a slog.Handler backed by a Logging instance.
+// The global Logs instance is used if logger is nil.
+//
+// Parameters:
+//   - logger - logger to write records to
+//
+// Returns:
+//   - *SlogHandler: handler which can be passed to slog.New
+func NewSlogHandler(logger *Logging) *SlogHandler {
+	if logger == nil {
+		logger = &Logs
+	}
+
+	return &SlogHandler{logger: logger}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+//
+// Parameters:
+//   - ctx - context
+//   - level - slog level
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	lev, _, _ := h.logger.ResolveLevel(slogLevel(level), nil)
+
+	return lev != ""
+}
+
+// Handle renders a record through Logging.
+// The process UUID is taken from the context by CtxKeyUUID,
+// the caller and the stack trace start from the PC of the record.
+//
+// Parameters:
+//   - ctx - context
+//   - r - record to handle
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	args := make([]any, 0, 2+len(h.fields)+r.NumAttrs())
+	args = append(args, ctx, r.Message)
+	args = append(args, h.fields...)
+	r.Attrs(func(a slog.Attr) bool {
+		args = appendSlogAttr(args, h.prefix, a)
+		return true
+	})
+
+	level := slogLevel(r.Level)
+	cfg := h.logger.config()
+	var pcs []uintptr
+	if depth := cfg.callersDepth(level); depth > 0 && r.PC != 0 {
+		pcs = callersFrom(r.PC, depth)
+	}
+
+	h.logger.logPC(&cfg, pcs, level, printFields, args)
+
+	return nil
+}
+
+// WithAttrs returns a handler which adds the attributes to every record.
+//
+// Parameters:
+//   - attrs - attributes to add
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	child := *h
+	child.fields = h.fields[:len(h.fields):len(h.fields)]
+	for _, a := range attrs {
+		child.fields = appendSlogAttr(child.fields, h.prefix, a)
+	}
+
+	return &child
+}
+
+// WithGroup returns a handler which qualifies keys of following attributes by the group name.
+//
+// Parameters:
+//   - name - group name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	child := *h
+	child.prefix += name + "."
+
+	return &child
+}
+
+// appendSlogAttr appends an attribute as fields, expanding groups.
+//
+// Parameters:
+//   - fields - fields to append to
+//   - prefix - key prefix
+//   - a - attribute to append
+func appendSlogAttr(fields []any, prefix string, a slog.Attr) []any {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendSlogAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+
+	return append(fields, Field{Key: prefix + a.Key, Value: a.Value.Any()})
+}
+
+// slogLevel converts a slog level to a log level
+//
+// Parameters:
+//   - level - slog level
+func slogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return LevelTrace
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
+
+// SlogLogger adapts a *slog.Logger to the Logger and StructuredLogger interfaces,
+// so it can be used as a backend of CustomLogger.
+// A leading context.Context argument is passed to the context-aware slog methods.
+type SlogLogger struct {
+	Logger     *slog.Logger // Logger to write records to (default slog.Default())
+	DontStop   bool         // Do not stop process on fatal error
+	parent     *SlogLogger  // Logger whose Logger and DontStop are used (nil for a root logger)
+	callerSkip int          // Additional stack frames to skip when capturing the caller
+}
+
+// NewSlogLogger creates a Logger which writes to a *slog.Logger.
+//
+// Parameters:
+//   - logger - slog logger
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	return &SlogLogger{Logger: logger}
+}
+
+// NewSlogHandlerLogger creates a Logger which writes to a slog.Handler.
+//
+// Parameters:
+//   - handler - slog handler
+func NewSlogHandlerLogger(handler slog.Handler) *SlogLogger {
+	return &SlogLogger{Logger: slog.New(handler)}
+}
+
+// Debug logs a debug message.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (l *SlogLogger) Debug(args ...any) {
+	l.log(slog.LevelDebug, args)
+}
+
+// Info logs an informational message.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (l *SlogLogger) Info(args ...any) {
+	l.log(slog.LevelInfo, args)
+}
+
+// Warn logs a warning message.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (l *SlogLogger) Warn(args ...any) {
+	l.log(slog.LevelWarn, args)
+}
+
+// Error logs an error message.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (l *SlogLogger) Error(args ...any) {
+	l.log(slog.LevelError, args)
+}
+
+// Fatal logs a fatal error message as an error record, flushes a SlogHandler and exits the program.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or format string
+//     # args[1] - format string (if args[0] is context)
+//     # args[2:] - arguments to format string
+func (l *SlogLogger) Fatal(args ...any) {
+	l.log(slog.LevelError, args)
+	l.exit()
+}
+
+// Debugw logs a debug message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (l *SlogLogger) Debugw(args ...any) {
+	l.logw(slog.LevelDebug, args)
+}
+
+// Infow logs an informational message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (l *SlogLogger) Infow(args ...any) {
+	l.logw(slog.LevelInfo, args)
+}
+
+// Warnw logs a warning message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (l *SlogLogger) Warnw(args ...any) {
+	l.logw(slog.LevelWarn, args)
+}
+
+// Errorw logs an error message with structured fields.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (l *SlogLogger) Errorw(args ...any) {
+	l.logw(slog.LevelError, args)
+}
+
+// Fatalw logs a fatal error message with structured fields as an error record, flushes a SlogHandler and exits the program.
+//
+// Parameters:
+//   - args - arguments to print
+//     # args[0] - context (optional) or message
+//     # args[1] - message (if args[0] is context) or first field
+//     # args[2:] - fields as Field values or alternating keys and values
+func (l *SlogLogger) Fatalw(args ...any) {
+	l.logw(slog.LevelError, args)
+	l.exit()
+}
+
+// log writes a formatted message to the slog logger.
+//
+// Parameters:
+//   - level - slog level
+//   - args - an optional context, a format string and arguments
+func (l *SlogLogger) log(level slog.Level, args []any) {
+	ctx, args := splitContext(args)
+
+	logger := l.slogLogger()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	r := slog.NewRecord(time.Now(), level, sprintf(args), l.callerPC())
+	logger.Handler().Handle(ctx, r)
+}
+
+// logw writes a message with fields to the slog logger.
+//
+// Parameters:
+//   - level - slog level
+//   - args - an optional context, a message and fields
+func (l *SlogLogger) logw(level slog.Level, args []any) {
+	ctx, args := splitContext(args)
+
+	logger := l.slogLogger()
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	var msg string
+	if len(args) > 0 {
+		msg = fmt.Sprint(args[0])
+		args = args[1:]
+	}
+
+	r := slog.NewRecord(time.Now(), level, msg, l.callerPC())
+	for _, f := range fieldsFromArgs(args) {
+		r.AddAttrs(slog.Any(f.Key, f.Value))
+	}
+
+	logger.Handler().Handle(ctx, r)
+}
+
+// callerPC returns the program counter of the code which called a SlogLogger method.
+// It must be called from log or logw.
+func (l *SlogLogger) callerPC() uintptr {
+	var pcs [1]uintptr
+	// skip runtime.Callers, callerPC, log or logw and the SlogLogger method
+	runtime.Callers(4+l.callerSkip, pcs[:])
+
+	return pcs[0]
+}
+
+// exit flushes the handler and exits the program with status code 1 unless DontStop is set.
+func (l *SlogLogger) exit() {
+	if !l.root().DontStop {
+		l.flush()
+		os.Exit(1) // Exit with status code 1
+	}
+}
+
+// flush flushes the Logging of a SlogHandler, waiting at most exitFlushTimeout.
+// Other handlers aren't flushed.
+func (l *SlogLogger) flush() {
+	if h, ok := l.slogLogger().Handler().(*SlogHandler); ok {
+		h.logger.flushTimeout(exitFlushTimeout)
+	}
+}
+
+// slogLogger returns the slog logger or slog.Default() if it isn't set.
+func (l *SlogLogger) slogLogger() *slog.Logger {
+	if logger := l.root().Logger; logger != nil {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+// root returns the root logger which holds Logger and DontStop.
+func (l *SlogLogger) root() *SlogLogger {
+	if l.parent != nil {
+		return l.parent
+	}
+
+	return l
+}
+
+// splitContext separates an optional leading context from arguments.
+// context.Background() is returned if there is no context.
+//
+// Parameters:
+//   - args - arguments
+func splitContext(args []any) (context.Context, []any) {
+	if len(args) > 0 {
+		if ctx, ok := args[0].(context.Context); ok {
+			return ctx, args[1:]
+		}
+	}
+
+	return context.Background(), args
+}
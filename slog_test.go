@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{
+		UUID:     "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		LogLevel: 4,
+		Output:   &buf,
+	}
+
+	log := slog.New(NewSlogHandler(logger))
+	ctx := context.WithValue(context.Background(), CtxKeyUUID, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	log.Debug("hidden")
+	log.InfoContext(ctx, "request", "method", "GET", slog.Group("http", "status", 200))
+	log.With("component", "billing").WithGroup("invoice").Warn("overdue", "id", 17, slog.Group("", "inline", true))
+	log.ErrorContext(ctx, "failed", slog.Group("empty"))
+
+	want := "INF\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\trequest\tmethod=GET http.status=200\n" +
+		"WRN\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\toverdue\tcomponent=billing invoice.id=17 invoice.inline=true\n" +
+		"ERR\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tfailed\n"
+	require.Equal(t, want, buf.String())
+
+	h := NewSlogHandler(logger)
+	require.False(t, h.Enabled(ctx, slog.LevelDebug))
+	require.True(t, h.Enabled(ctx, slog.LevelInfo))
+	require.True(t, h.Enabled(ctx, slog.LevelError))
+}
+
+func TestSlogLevel(t *testing.T) {
+	testCases := []struct {
+		level slog.Level
+		want  Level
+	}{
+		{slog.LevelDebug - 4, LevelTrace},
+		{slog.LevelDebug, LevelDebug},
+		{slog.LevelInfo - 1, LevelDebug},
+		{slog.LevelInfo, LevelInfo},
+		{slog.LevelWarn, LevelWarn},
+		{slog.LevelError, LevelError},
+		{slog.LevelError + 4, LevelError},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, slogLevel(tc.level), "slogLevel(%v)", tc.level)
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{
+		UUID:   "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049",
+		Output: &buf,
+	}
+
+	sLog := NewSlogHandlerLogger(NewSlogHandler(logger))
+
+	logs := &CustomLogger{}
+	logs.SetLogger(sLog)
+	sLog.DontStop = true // Prevent exit on fatal error, options set after SetLogger are used
+
+	ctx := context.WithValue(context.Background(), CtxKeyUUID, "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+
+	logs.Debug(ctx, "Hello %s", "World")
+	logs.Info("Hello World")
+	logs.Warnw(ctx, "fields", "user_id", 42)
+	logs.Error(ctx, "100%")
+	logs.Fatal(ctx, "fatal")
+
+	want := "DBG\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tHello World\n" +
+		"INF\t[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049]\tHello World\n" +
+		"WRN\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tfields\tuser_id=42\n" +
+		"ERR\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\t100%\n" +
+		"ERR\t[4577c272-e9b8-4a19-a9d0-4ec0bde6063f]\tfatal\n"
+	require.Equal(t, want, buf.String())
+
+	buf.Reset()
+	text := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+
+	text.Info("hidden")
+	text.Warn(ctx, "count: %d", 3)
+	require.Equal(t, "level=WARN msg=\"count: 3\"\n", buf.String())
+}
+
+func TestSlogLogger_Flush(t *testing.T) {
+	defer func(timeout time.Duration) { exitFlushTimeout = timeout }(exitFlushTimeout)
+	exitFlushTimeout = 20 * time.Millisecond
+
+	sink := newGatedSink()
+	async := NewAsyncSink(sink, AsyncOptions{})
+	sLog := NewSlogHandlerLogger(NewSlogHandler(&Logging{Sink: async}))
+
+	// A stuck sink doesn't block the flush before exit
+	sLog.Info("stuck")
+	<-sink.started
+	start := time.Now()
+	sLog.flush()
+	require.Less(t, time.Since(start), time.Second)
+
+	// Buffered entries are written before exit
+	close(sink.gate)
+	exitFlushTimeout = time.Second
+	sLog.Info("buffered")
+	sLog.flush()
+	require.Equal(t, []string{"stuck", "buffered"}, sink.msgs)
+	require.NoError(t, async.Close())
+
+	// Handlers of other types aren't flushed
+	NewSlogLogger(slog.New(slog.DiscardHandler)).flush()
+}
@@ -0,0 +1,145 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// defaultStackDepth is the maximal number of stack frames if StackDepth is not set
+const defaultStackDepth = 32
+
+// formatStack formats program counters returned by runtime.Callers as a stack trace,
+// two lines per frame:
+//
+//	github.com/ra-company/logging.(*Logging).Error
+//		/src/logging/logging.go:42
+//
+// Parameters:
+//   - pcs - program counters
+func formatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+
+	var b []byte
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && frame.Function != "runtime.goexit" {
+			if len(b) > 0 {
+				b = append(b, '\n')
+			}
+			b = append(b, frame.Function...)
+			b = append(b, "\n\t"...)
+			b = append(b, frame.File...)
+			b = append(b, ':')
+			b = strconv.AppendInt(b, int64(frame.Line), 10)
+		}
+		if !more {
+			break
+		}
+	}
+
+	return string(b)
+}
+
+// stackFromArgs returns the stack trace carried by the first error argument
+// which has a StackTrace method (pkg/errors style), or an empty string.
+// Errors wrapped by such errors are searched as well, the deepest stack trace is used.
+//
+// Parameters:
+//   - args - logged arguments, Field values are inspected too
+//   - depth - maximal number of stack frames (0 means 32)
+func stackFromArgs(args []any, depth int) string {
+	if depth <= 0 {
+		depth = defaultStackDepth
+	}
+
+	for _, arg := range args {
+		if f, ok := arg.(Field); ok {
+			arg = f.Value
+		}
+
+		err, ok := arg.(error)
+		if !ok {
+			continue
+		}
+
+		var stack string
+		for ; !nilError(err); err = errors.Unwrap(err) {
+			if s := stackTrace(err, depth); s != "" {
+				stack = s
+			}
+		}
+		if stack != "" {
+			return stack
+		}
+	}
+
+	return ""
+}
+
+// stackTrace calls the StackTrace method of v if it has one.
+// Results which are slices of program counters, like errors.StackTrace of pkg/errors,
+// are formatted by formatStack, other results are formatted with the "%+v" verb.
+//
+// Parameters:
+//   - v - value to get the stack trace of
+//   - depth - maximal number of stack frames
+func stackTrace(v any, depth int) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		// the method of a nil pointer would panic
+		return ""
+	}
+
+	m := rv.MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return ""
+	}
+
+	st := m.Call(nil)[0]
+	if st.Kind() == reflect.Slice && st.Type().Elem().Kind() == reflect.Uintptr {
+		pcs := make([]uintptr, min(st.Len(), depth))
+		for i := range pcs {
+			pcs[i] = uintptr(st.Index(i).Uint())
+		}
+		return formatStack(pcs)
+	}
+
+	lines := strings.Split(strings.Trim(fmt.Sprintf("%+v", st.Interface()), "\n"), "\n")
+	if len(lines) > 2*depth {
+		lines = lines[:2*depth]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// callersFrom returns up to depth program counters of the current goroutine stack
+// starting with the frame of pc, e.g. the PC of a slog.Record.
+// Only pc is returned if the stack doesn't contain it.
+//
+// Parameters:
+//   - pc - program counter of the first frame
+//   - depth - maximal number of stack frames
+func callersFrom(pc uintptr, depth int) []uintptr {
+	if depth == 1 {
+		return []uintptr{pc}
+	}
+
+	// reserve frames for slog and handlers between the caller and callersFrom
+	pcs := make([]uintptr, depth+16)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	for i, p := range pcs {
+		if p == pc {
+			return pcs[i:min(i+depth, len(pcs))]
+		}
+	}
+
+	return []uintptr{pc}
+}
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stackSink records stack traces of written entries
+type stackSink struct {
+	stacks []string
+}
+
+func (s *stackSink) WriteEntry(e *Entry) error {
+	s.stacks = append(s.stacks, e.Stack)
+	return nil
+}
+
+// frame mimics errors.Frame of pkg/errors
+type frame uintptr
+
+// frameError mimics errors created by pkg/errors
+type frameError struct {
+	msg    string
+	frames []frame
+}
+
+func (e *frameError) Error() string { return e.msg }
+
+func (e *frameError) StackTrace() []frame { return e.frames }
+
+// newFrameError creates an error with the stack of its caller
+func newFrameError(msg string) error {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	frames := make([]frame, len(pcs))
+	for i, pc := range pcs {
+		frames[i] = frame(pc)
+	}
+
+	return &frameError{msg: msg, frames: frames}
+}
+
+// textStack is a stack trace type which is only printable
+type textStack string
+
+// textError has a stack trace which is formatted by fmt
+type textError struct{}
+
+func (textError) Error() string { return "text" }
+
+func (textError) StackTrace() textStack {
+	return "\nmain.f\n\t/src/main.go:1\nmain.main\n\t/src/main.go:2"
+}
+
+func TestLogging_Stack(t *testing.T) {
+	sink := &stackSink{}
+	logger := &Logging{Sink: sink, ShowStack: true}
+
+	want := line() + 1
+	logger.Error("failed")
+	logger.Warn("not attached")
+
+	require.Len(t, sink.stacks, 2)
+	require.True(t, strings.HasPrefix(sink.stacks[0], "github.com/ra-company/logging.TestLogging_Stack\n\t"), sink.stacks[0])
+	require.Contains(t, strings.SplitN(sink.stacks[0], "\n", 3)[1], "/stack_test.go:"+strconv.Itoa(want))
+	require.Empty(t, sink.stacks[1])
+
+	// Minimal level and depth
+	sink.stacks = nil
+	logger.SetStack(true, LevelWarn, 1)
+	logger.Warn("attached")
+	logger.Info("not attached")
+
+	require.Len(t, sink.stacks, 2)
+	require.Len(t, strings.Split(sink.stacks[0], "\n"), 2)
+	require.Empty(t, sink.stacks[1])
+
+	// Every level can be chosen, LevelDebug is not the default
+	sink.stacks = nil
+	logger.SetStack(true, LevelDebug, 1)
+	logger.Debug("attached")
+	logger.Info("attached")
+
+	require.Len(t, sink.stacks, 2)
+	require.NotEmpty(t, sink.stacks[0])
+	require.NotEmpty(t, sink.stacks[1])
+
+	// The stack trace of a logged error is used instead of the logging site
+	sink.stacks = nil
+	logger.SetStack(true, LevelError, 0)
+	origin := line() + 1
+	err := newFrameError("origin")
+	logger.Error(fmt.Errorf("wrapped: %w", err))
+	logger.Errorw("failed", Err(err))
+	logger.Errorw("failed", "error", textError{})
+
+	require.Len(t, sink.stacks, 3)
+	require.Contains(t, strings.SplitN(sink.stacks[0], "\n", 3)[1], "/stack_test.go:"+strconv.Itoa(origin))
+	require.Equal(t, sink.stacks[0], sink.stacks[1])
+	require.Equal(t, "main.f\n\t/src/main.go:1\nmain.main\n\t/src/main.go:2", sink.stacks[2])
+
+	// Stack traces start from the caller of slog
+	sink.stacks = nil
+	slog.New(NewSlogHandler(logger)).Error("slog")
+	require.True(t, strings.HasPrefix(sink.stacks[0], "github.com/ra-company/logging.TestLogging_Stack\n\t"), sink.stacks[0])
+}
+
+func TestStackTrace_Depth(t *testing.T) {
+	require.Equal(t, "main.f\n\t/src/main.go:1", stackTrace(textError{}, 1))
+	require.Empty(t, stackTrace(errors.New("plain"), 1))
+
+	stack := stackTrace(newFrameError("origin"), 2)
+	require.Len(t, strings.Split(stack, "\n"), 4)
+}
+
+func TestEncoders_Stack(t *testing.T) {
+	entry := &Entry{
+		Level:   LevelError,
+		UUID:    "id",
+		Message: "failed",
+		Stack:   "main.f\n\t/src/main.go:1\nmain.main\n\t/src/main.go:2",
+	}
+
+	require.Equal(t, "ERR\t[id]\tfailed\n\tmain.f\n\t\t/src/main.go:1\n\tmain.main\n\t\t/src/main.go:2\n", string(TextEncoder{}.Encode(nil, entry)))
+	require.Equal(t, `{"level":"error","uuid":"id","msg":"failed","stack":"main.f\n\t/src/main.go:1\nmain.main\n\t/src/main.go:2"}`+"\n", string(JSONEncoder{}.Encode(nil, entry)))
+	require.Equal(t, `level=error uuid=id msg=failed stack="main.f\n\t/src/main.go:1\nmain.main\n\t/src/main.go:2"`+"\n", string(LogfmtEncoder{}.Encode(nil, entry)))
+}
@@ -0,0 +1,280 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyslogFormat is a syslog message format.
+type SyslogFormat int
+
+const (
+	SyslogDefault SyslogFormat = iota // RFC 3164 for local sockets, RFC 5424 otherwise
+	SyslogRFC5424                     // RFC 5424 messages with structured data
+	SyslogRFC3164                     // BSD syslog messages
+)
+
+// Facility is a syslog facility. The zero value means FacilityUser.
+type Facility int
+
+const (
+	FacilityUser     Facility = 1
+	FacilityMail     Facility = 2
+	FacilityDaemon   Facility = 3
+	FacilityAuth     Facility = 4
+	FacilitySyslog   Facility = 5
+	FacilityLPR      Facility = 6
+	FacilityNews     Facility = 7
+	FacilityUUCP     Facility = 8
+	FacilityCron     Facility = 9
+	FacilityAuthPriv Facility = 10
+	FacilityFTP      Facility = 11
+	FacilityLocal0   Facility = 16
+	FacilityLocal1   Facility = 17
+	FacilityLocal2   Facility = 18
+	FacilityLocal3   Facility = 19
+	FacilityLocal4   Facility = 20
+	FacilityLocal5   Facility = 21
+	FacilityLocal6   Facility = 22
+	FacilityLocal7   Facility = 23
+)
+
+// localSyslogPaths are sockets of the local syslog daemon
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogSink is a Sink which sends entries to syslog over a unix socket or UDP/TCP.
+// The connection is reestablished automatically when it drops.
+type SyslogSink struct {
+	Network  string       // Network: "udp", "tcp", "unix", "unixgram" or empty for the local syslog socket
+	Address  string       // Address of the syslog server or socket path
+	Format   SyslogFormat // Message format
+	Facility Facility     // Syslog facility (default FacilityUser)
+	AppName  string       // Application name (default title passed to Starting or the executable name)
+	Hostname string       // Host name (default os.Hostname())
+	SDID     string       // Structured data ID for the process UUID in RFC 5424 (default "meta@32473")
+
+	mu      sync.Mutex
+	conn    net.Conn
+	network string // Network of the established connection
+}
+
+// WriteEntry sends an entry to syslog, reconnecting once if sending fails.
+//
+// Parameters:
+//   - e - entry to send
+func (s *SyslogSink) WriteEntry(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if err = s.connect(); err != nil {
+				continue
+			}
+		}
+
+		if _, err = s.conn.Write(s.format(e)); err == nil {
+			return nil
+		}
+
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return err
+}
+
+// Close closes the connection.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+// connect establishes a connection to the syslog server or the local socket.
+func (s *SyslogSink) connect() error {
+	if s.Network != "" {
+		conn, err := net.DialTimeout(s.Network, s.Address, 5*time.Second)
+		if err != nil {
+			return err
+		}
+		s.conn, s.network = conn, s.Network
+		return nil
+	}
+
+	paths := localSyslogPaths
+	if s.Address != "" {
+		paths = []string{s.Address}
+	}
+
+	for _, path := range paths {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.Dial(network, path); err == nil {
+				s.conn, s.network = conn, network
+				return nil
+			}
+		}
+	}
+
+	return errors.New("local syslog socket is not available")
+}
+
+// format builds a syslog message with framing for the connection type.
+//
+// Parameters:
+//   - e - entry to format
+func (s *SyslogSink) format(e *Entry) []byte {
+	format := s.Format
+	local := s.Network == "" || strings.HasPrefix(s.Network, "unix")
+	if format == SyslogDefault {
+		format = SyslogRFC5424
+		if local {
+			format = SyslogRFC3164
+		}
+	}
+
+	facility := s.Facility
+	if facility == 0 {
+		facility = FacilityUser
+	}
+	pri := int(facility)*8 + syslogSeverity(e.Level)
+
+	t := e.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	appName := s.AppName
+	if appName == "" {
+		appName = e.Title
+	}
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+
+	msg := e.Message
+	if e.Logger != "" {
+		msg = e.Logger + ": " + msg
+	}
+	if len(e.Fields) > 0 {
+		msg = string(appendTextFields(append([]byte(msg), '\t'), e.Fields))
+	}
+
+	var b []byte
+	if format == SyslogRFC3164 {
+		b = fmt.Appendf(nil, "<%d>%s ", pri, t.Format(time.Stamp))
+		if !local {
+			b = append(b, s.hostname()...)
+			b = append(b, ' ')
+		}
+		b = fmt.Appendf(b, "%s[%d]: [%s] %s", syslogName(appName, 32), os.Getpid(), e.UUID, msg)
+	} else {
+		sdID := s.SDID
+		if sdID == "" {
+			sdID = "meta@32473"
+		}
+
+		b = fmt.Appendf(nil, "<%d>1 %s %s %s %d - ", pri, t.Format("2006-01-02T15:04:05.000000Z07:00"),
+			syslogName(s.hostname(), 255), syslogName(appName, 48), os.Getpid())
+		if e.UUID != "" {
+			b = fmt.Appendf(b, "[%s uuid=\"%s\"] ", syslogName(sdID, 32), escapeSDValue(e.UUID))
+		} else {
+			b = append(b, "- "...)
+		}
+		b = append(b, msg...)
+	}
+
+	switch {
+	case s.network == "tcp" || s.network == "tcp4" || s.network == "tcp6":
+		if format == SyslogRFC5424 {
+			return append(strconv.AppendInt(nil, int64(len(b)), 10), append([]byte{' '}, b...)...)
+		}
+		return append(b, '\n')
+	case s.network == "unix":
+		return append(b, '\n')
+	}
+
+	return b
+}
+
+// hostname returns the configured or the system host name.
+func (s *SyslogSink) hostname() string {
+	if s.Hostname != "" {
+		return s.Hostname
+	}
+
+	if name, err := os.Hostname(); err == nil && name != "" {
+		return name
+	}
+
+	return "-"
+}
+
+// syslogSeverity converts a log level to a syslog severity
+//
+// Parameters:
+//   - level - log level
+func syslogSeverity(level Level) int {
+	switch {
+	case level <= LevelDebug:
+		return 7 // debug
+	case level == LevelInfo:
+		return 6 // informational
+	case level == LevelWarn:
+		return 4 // warning
+	case level == LevelError:
+		return 3 // error
+	case level == LevelFatal:
+		return 2 // critical
+	default:
+		return 1 // alert
+	}
+}
+
+// syslogName replaces characters which are not allowed in syslog header fields
+// and truncates the value to the maximum length.
+//
+// Parameters:
+//   - name - header field value
+//   - max - maximum length
+func syslogName(name string, max int) string {
+	name = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if name == "" {
+		return "-"
+	}
+	if len(name) > max {
+		return name[:max]
+	}
+
+	return name
+}
+
+// escapeSDValue escapes '"', '\' and ']' in a structured data parameter value.
+//
+// Parameters:
+//   - value - parameter value
+func escapeSDValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
@@ -0,0 +1,135 @@
+package logging
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogSink_UDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink := &SyslogSink{
+		Network:  "udp",
+		Address:  conn.LocalAddr().String(),
+		Facility: FacilityLocal3,
+		Hostname: "host",
+	}
+	defer sink.Close()
+
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Sink: sink}
+	logger.Starting("billing")
+	logger.Errorw("payment failed", "order", 17)
+
+	buf := make([]byte, 2048)
+	pid := os.Getpid()
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(fmt.Sprintf(
+		`^<158>1 \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}\S+ host billing %d - \[meta@32473 uuid="b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"\] billing service is starting...$`,
+		pid)), string(buf[:n]))
+
+	n, _, err = conn.ReadFrom(buf)
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(fmt.Sprintf(
+		`^<155>1 \S+ host billing %d - \[meta@32473 uuid="b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049"\] payment failed\torder=17$`,
+		pid)), string(buf[:n]))
+}
+
+func TestSyslogSink_Local(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink := &SyslogSink{Address: path, AppName: "app"}
+	defer sink.Close()
+
+	logger := &Logging{UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Sink: sink}
+	logger.Warn("disk is almost full")
+
+	buf := make([]byte, 2048)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(fmt.Sprintf(
+		`^<12>\w{3} [ \d]\d \d\d:\d\d:\d\d app\[%d\]: \[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049\] disk is almost full$`,
+		os.Getpid())), string(buf[:n]))
+}
+
+func TestSyslogSink_Reconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	messages := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					var size int
+					if _, err := fmt.Fscanf(r, "%d ", &size); err != nil {
+						return
+					}
+					msg := make([]byte, size)
+					if _, err := io.ReadFull(r, msg); err != nil {
+						return
+					}
+					messages <- string(msg)
+				}
+			}()
+		}
+	}()
+
+	sink := &SyslogSink{Network: "tcp", Address: ln.Addr().String(), Hostname: "host", AppName: "app"}
+	defer sink.Close()
+
+	entry := &Entry{Level: LevelInfo, UUID: "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", Message: "first"}
+	require.NoError(t, sink.WriteEntry(entry))
+	require.Contains(t, <-messages, `<14>1 `)
+
+	// The connection drops
+	sink.conn.Close()
+
+	entry.Message = "second"
+	entry.Level = LevelFatal
+	require.NoError(t, sink.WriteEntry(entry))
+
+	msg := <-messages
+	require.Contains(t, msg, `<10>1 `)
+	require.Contains(t, msg, `] second`)
+}
+
+func TestSyslogSeverity(t *testing.T) {
+	testCases := map[Level]int{
+		LevelTrace: 7,
+		LevelDebug: 7,
+		LevelInfo:  6,
+		LevelWarn:  4,
+		LevelError: 3,
+		LevelFatal: 2,
+		LevelPanic: 1,
+	}
+
+	for level, want := range testCases {
+		require.Equal(t, want, syslogSeverity(level), "syslogSeverity(%v)", level)
+	}
+}
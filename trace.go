@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidTraceParent is returned by ParseTraceParent for malformed values.
+var ErrInvalidTraceParent = errors.New("logging: invalid traceparent")
+
+// TraceContext identifies the trace and the span of a log entry as defined by W3C Trace Context.
+type TraceContext struct {
+	TraceID string // Trace ID, 32 lowercase hex digits
+	SpanID  string // Span ID, 16 lowercase hex digits
+	Flags   byte   // Trace flags, bit 0 is the sampled flag
+}
+
+// IsValid reports whether trace and span IDs are set.
+func (tc TraceContext) IsValid() bool {
+	return tc.TraceID != "" && tc.SpanID != ""
+}
+
+// Sampled reports whether the sampled flag is set.
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&1 != 0
+}
+
+// String returns the trace context as a traceparent header value, e.g.
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func (tc TraceContext) String() string {
+	return "00-" + tc.TraceID + "-" + tc.SpanID + "-" + hex.EncodeToString([]byte{tc.Flags})
+}
+
+// fields returns trace_id, span_id and trace_flags fields.
+func (tc TraceContext) fields() []Field {
+	return []Field{
+		String("trace_id", tc.TraceID),
+		String("span_id", tc.SpanID),
+		String("trace_flags", hex.EncodeToString([]byte{tc.Flags})),
+	}
+}
+
+// ParseTraceParent parses a W3C traceparent header value.
+// Values of future versions are accepted if they start with the version 00 fields.
+//
+// Parameters:
+//   - traceparent - header value, e.g. "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+func ParseTraceParent(traceparent string) (TraceContext, error) {
+	s := strings.TrimSpace(traceparent)
+	if len(s) < 55 || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return TraceContext{}, ErrInvalidTraceParent
+	}
+
+	version, traceID, spanID, flags := s[0:2], s[3:35], s[36:52], s[53:55]
+	if !isLowerHex(version) || version == "ff" || (version == "00" && len(s) != 55) || (len(s) > 55 && s[55] != '-') {
+		return TraceContext{}, ErrInvalidTraceParent
+	}
+	if !isLowerHex(traceID) || traceID == strings.Repeat("0", 32) ||
+		!isLowerHex(spanID) || spanID == strings.Repeat("0", 16) || !isLowerHex(flags) {
+		return TraceContext{}, ErrInvalidTraceParent
+	}
+
+	b, _ := hex.DecodeString(flags)
+
+	return TraceContext{TraceID: traceID, SpanID: spanID, Flags: b[0]}, nil
+}
+
+// isLowerHex reports whether s consists of lowercase hex digits.
+//
+// Parameters:
+//   - s - string to check
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TraceExtractor extracts the trace context from a context.Context,
+// e.g. from an OpenTelemetry span. It lets Logging add trace_id, span_id and trace_flags fields
+// to entries without depending on a tracing SDK.
+type TraceExtractor interface {
+	// ExtractTrace returns the trace context and true if ctx has an active trace.
+	ExtractTrace(ctx context.Context) (TraceContext, bool)
+}
+
+// TraceExtractorFunc is an adapter to use a function as a TraceExtractor.
+type TraceExtractorFunc func(ctx context.Context) (TraceContext, bool)
+
+// ExtractTrace calls f(ctx).
+//
+// Parameters:
+//   - ctx - context
+func (f TraceExtractorFunc) ExtractTrace(ctx context.Context) (TraceContext, bool) {
+	return f(ctx)
+}
+
+// traceKey is the context key of the trace context
+type traceKey struct{}
+
+// ContextWithTrace returns a copy of ctx which carries the trace context.
+//
+// Parameters:
+//   - ctx - parent context
+//   - tc - trace context
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, tc)
+}
+
+// ContextWithTraceParent returns a copy of ctx which carries a W3C traceparent value,
+// e.g. the traceparent header of an incoming request. Invalid values are ignored by TraceFromContext.
+//
+// Parameters:
+//   - ctx - parent context
+//   - traceparent - traceparent header value
+func ContextWithTraceParent(ctx context.Context, traceparent string) context.Context {
+	return context.WithValue(ctx, traceKey{}, traceparent)
+}
+
+// TraceFromContext returns the trace context stored by ContextWithTrace or ContextWithTraceParent.
+// It is the default TraceExtractor of Logging.
+//
+// Parameters:
+//   - ctx - context
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	switch v := ctx.Value(traceKey{}).(type) {
+	case TraceContext:
+		return v, v.IsValid()
+	case string:
+		tc, err := ParseTraceParent(v)
+		return tc, err == nil
+	}
+
+	return TraceContext{}, false
+}
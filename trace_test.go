@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tc, err := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.NoError(t, err)
+	require.Equal(t, TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Flags: 1}, tc)
+	require.True(t, tc.Sampled())
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", tc.String())
+
+	// Future versions may have more fields
+	tc, err = ParseTraceParent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra")
+	require.NoError(t, err)
+	require.False(t, tc.Sampled())
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0x",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+	for _, s := range invalid {
+		_, err := ParseTraceParent(s)
+		require.ErrorIs(t, err, ErrInvalidTraceParent, "value %q", s)
+	}
+}
+
+func TestTraceFromContext(t *testing.T) {
+	_, ok := TraceFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := ContextWithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	tc, ok := TraceFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "00f067aa0ba902b7", tc.SpanID)
+
+	_, ok = TraceFromContext(ContextWithTraceParent(context.Background(), "invalid"))
+	require.False(t, ok)
+
+	ctx = ContextWithTrace(context.Background(), TraceContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"})
+	tc, ok = TraceFromContext(ctx)
+	require.True(t, ok)
+	require.False(t, tc.Sampled())
+}
+
+// spanKey is the context key of the test span
+type spanKey struct{}
+
+func TestLogging_Trace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "id", Output: &buf, Encoder: JSONEncoder{}}
+
+	ctx := ContextWithTraceParent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	logger.With("component", "billing").Infow(ctx, "charged", "amount", 10)
+	logger.Info(context.Background(), "no trace")
+	logger.Info("no context")
+
+	want := `{"level":"info","uuid":"id","msg":"charged","component":"billing","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","trace_flags":"01","amount":10}` + "\n" +
+		`{"level":"info","uuid":"id","msg":"no trace"}` + "\n" +
+		`{"level":"info","uuid":"id","msg":"no context"}` + "\n"
+	require.Equal(t, want, buf.String())
+
+	// A custom extractor, e.g. for OpenTelemetry spans
+	buf.Reset()
+	logger.SetEncoder(nil)
+	logger.SetTraceExtractor(TraceExtractorFunc(func(ctx context.Context) (TraceContext, bool) {
+		tc, ok := ctx.Value(spanKey{}).(TraceContext)
+		return tc, ok
+	}))
+
+	ctx = context.WithValue(context.Background(), spanKey{}, TraceContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"})
+	logger.Warn(ctx, "slow")
+	require.Equal(t, "WRN\t[id]\tslow\ttrace_id=0af7651916cd43dd8448eb211c80319c span_id=b7ad6b7169203331 trace_flags=00\n", buf.String())
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+)
+
+// Transport is an http.RoundTripper which propagates the process UUID to other services
+// and logs outbound requests with method, host, status, duration and error fields.
+//
+// The UUID is taken from the request context by UUIDFromContext, so it honours ContextWithUUID and CtxKeyUUID,
+// and is sent in the request ID header unless the request already has it.
+// Combined with NewMiddleware on the other side, one UUID follows a request through all services.
+type Transport struct {
+	Base   http.RoundTripper                                           // Transport which sends requests (default http.DefaultTransport)
+	Logger *Logging                                                    // Logger for outbound requests (default Logs)
+	Header string                                                      // Request ID header (default "X-Request-ID")
+	Level  func(r *http.Request, resp *http.Response, err error) Level // Level of log entries (default TransportLevel)
+}
+
+// RoundTrip sends the request with the request ID header and logs the result.
+//
+// Parameters:
+//   - r - request
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	header := t.Header
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+
+	ctx := r.Context()
+	if id, ok := UUIDFromContext(ctx); ok && r.Header.Get(header) == "" {
+		// a RoundTripper must not modify the request
+		r = r.Clone(ctx)
+		r.Header.Set(header, id)
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(r)
+	duration := time.Since(start)
+
+	logger := t.Logger
+	if logger == nil {
+		logger = &Logs
+	}
+
+	levelFunc := t.Level
+	if levelFunc == nil {
+		levelFunc = TransportLevel
+	}
+
+	args := []any{ctx, "http client request", "method", r.Method, "host", r.URL.Host}
+	if resp != nil {
+		args = append(args, "status", resp.StatusCode)
+	}
+	args = append(args, Duration("duration", duration))
+	if err != nil {
+		args = append(args, Err(err))
+	}
+
+	logger.Logw(levelFunc(r, resp, err), args...)
+
+	return resp, err
+}
+
+// TransportLevel returns the level of an outbound request log entry:
+// LevelError for failed requests and 5xx responses, LevelWarn for 4xx and LevelInfo for other responses.
+//
+// Parameters:
+//   - r - request
+//   - resp - response (nil if the request failed)
+//   - err - request error
+func TransportLevel(r *http.Request, resp *http.Response, err error) Level {
+	if err != nil || resp == nil {
+		return LevelError
+	}
+
+	return AccessLevel(r, resp.StatusCode)
+}
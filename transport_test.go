@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripFunc is an adapter to use a function as an http.RoundTripper
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestTransport(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("X-Request-ID"))
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "id", Output: &buf}
+	client := &http.Client{Transport: &Transport{Logger: logger}}
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	ctx := ContextWithUUID(context.Background(), "4577c272-e9b8-4a19-a9d0-4ec0bde6063f")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/orders", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Empty(t, req.Header.Get("X-Request-ID"), "request of the caller must not be modified")
+
+	// The old context key is honoured, an existing header is kept
+	ctx = context.WithValue(context.Background(), CtxKeyUUID, "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049")
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/missing", nil)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	req.Header.Set("X-Request-ID", "explicit")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, []string{"4577c272-e9b8-4a19-a9d0-4ec0bde6063f", "b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049", "explicit"}, received)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	require.Regexp(t, regexp.MustCompile(`^INF\t\[4577c272-e9b8-4a19-a9d0-4ec0bde6063f\]\thttp client request\tmethod=GET host=`+regexp.QuoteMeta(host)+` status=200 duration=\S+$`), lines[0])
+	require.Regexp(t, regexp.MustCompile(`^WRN\t\[b846c7ab-9bc3-4c3a-b9e9-c65ae7bdd049\]\thttp client request\tmethod=POST host=\S+ status=404 duration=\S+$`), lines[1])
+}
+
+func TestTransport_Error(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logging{UUID: "id", Output: &buf}
+
+	var header string
+	transport := &Transport{
+		Base: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			header = r.Header.Get("X-Correlation-ID")
+			return nil, errors.New("connection refused")
+		}),
+		Logger: logger,
+		Header: "X-Correlation-ID",
+		Level: func(r *http.Request, resp *http.Response, err error) Level {
+			return LevelWarn
+		},
+	}
+
+	ctx := ContextWithUUID(context.Background(), "job-1")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, "http://billing.local/invoices/1", nil)
+	_, err := transport.RoundTrip(req)
+	require.EqualError(t, err, "connection refused")
+	require.Equal(t, "job-1", header)
+	require.Regexp(t, regexp.MustCompile(`^WRN\t\[job-1\]\thttp client request\tmethod=DELETE host=billing.local duration=\S+ error="connection refused"\n$`), buf.String())
+}
+
+func TestTransportLevel(t *testing.T) {
+	require.Equal(t, LevelError, TransportLevel(nil, nil, errors.New("timeout")))
+	require.Equal(t, LevelInfo, TransportLevel(nil, &http.Response{StatusCode: http.StatusOK}, nil))
+	require.Equal(t, LevelWarn, TransportLevel(nil, &http.Response{StatusCode: http.StatusConflict}, nil))
+	require.Equal(t, LevelError, TransportLevel(nil, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil))
+}